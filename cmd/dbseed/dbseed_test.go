@@ -1,11 +1,22 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"flag"
+	"io/ioutil"
+	"log"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/thrasher-corp/gocryptotrader/core"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+	"github.com/thrasher-corp/goose"
 	"github.com/urfave/cli/v2"
 )
 
@@ -30,6 +41,7 @@ var (
 		Commands: []*cli.Command{
 			seedExchangeCommand,
 			seedCandleCommand,
+			migrationCommand,
 		},
 	}
 )
@@ -43,3 +55,123 @@ func TestLoad(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func setupMigrationTest(t *testing.T) *database.Instance {
+	t.Helper()
+	var err error
+	testhelpers.MigrationDir = filepath.Join("..", "..", "database", "migrations")
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	conn, err := testhelpers.ConnectToDatabase(&database.Config{
+		Driver:            database.DBSQLite3,
+		ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func teardownMigrationTest(t *testing.T, conn *database.Instance) {
+	t.Helper()
+	err := testhelpers.CloseDatabase(conn)
+	if err != nil {
+		t.Error(err)
+	}
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		t.Errorf("Failed to remove temp db file: %v", err)
+	}
+}
+
+func TestRunMigrationStatus(t *testing.T) {
+	conn := setupMigrationTest(t)
+	defer teardownMigrationTest(t, conn)
+
+	migrations, err := ioutil.ReadDir(testhelpers.MigrationDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	goose.SetLogger(log.New(&out, "", 0))
+	defer goose.SetLogger(log.New(os.Stderr, "", log.LstdFlags))
+
+	err = runMigration(conn.SQL, repository.GetSQLDialect(), testhelpers.MigrationDir, "status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := out.String()
+	if strings.Contains(status, "Pending") {
+		t.Errorf("expected all migrations to be applied, received:\n%s", status)
+	}
+	for x := range migrations {
+		if !strings.Contains(status, migrations[x].Name()) {
+			t.Errorf("expected migration %s in status output", migrations[x].Name())
+		}
+	}
+	latest := strings.SplitN(migrations[len(migrations)-1].Name(), "_", 2)[0]
+	if !strings.Contains(status, "goose: version "+latest) {
+		t.Errorf("expected current schema version %s, received:\n%s", latest, status)
+	}
+}
+
+func TestRunMigrationDownTo(t *testing.T) {
+	conn := setupMigrationTest(t)
+	defer teardownMigrationTest(t, conn)
+
+	driver := repository.GetSQLDialect()
+	err := runMigration(conn.SQL, driver, testhelpers.MigrationDir, "down-to", "0")
+	if !errors.Is(err, errBelowBaselineVersion) {
+		t.Errorf("expected %v, received %v", errBelowBaselineVersion, err)
+	}
+	err = runMigration(conn.SQL, driver, testhelpers.MigrationDir, "down-to", "20201017103001")
+	if !errors.Is(err, errInvalidMigrationVersion) {
+		t.Errorf("expected %v, received %v", errInvalidMigrationVersion, err)
+	}
+	err = runMigration(conn.SQL, driver, testhelpers.MigrationDir, "down-to", "latest")
+	if !errors.Is(err, errInvalidMigrationVersion) {
+		t.Errorf("expected %v, received %v", errInvalidMigrationVersion, err)
+	}
+
+	err = runMigration(conn.SQL, driver, testhelpers.MigrationDir, "down-to", "20201017103000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, err := goose.GetDBVersion(conn.SQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 20201017103000 {
+		t.Errorf("expected %v, received %v", 20201017103000, version)
+	}
+
+	rows, err := conn.SQL.Query("PRAGMA table_info(candle)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var columns []string
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue interface{}
+		err = rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		columns = append(columns, name)
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) == 0 {
+		t.Fatal("expected candle table to remain")
+	}
+	for x := range columns {
+		if columns[x] == "quote_volume" {
+			t.Error("expected quote_volume column to be rolled back")
+		}
+	}
+}
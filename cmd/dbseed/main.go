@@ -32,6 +32,7 @@ var (
 		Commands: []*cli.Command{
 			seedExchangeCommand,
 			seedCandleCommand,
+			migrationCommand,
 		},
 	}
 	workingDir string
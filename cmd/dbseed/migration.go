@@ -0,0 +1,133 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/goose"
+	"github.com/urfave/cli/v2"
+)
+
+// migrationCommand exposes a subset of the dbmigrate commands, both run
+// through goose.Run so behaviour matches cmd/dbmigrate, use dbmigrate for
+// anything else
+var migrationCommand = &cli.Command{
+	Name:  "migration",
+	Usage: "inspect database migrations, see cmd/dbmigrate for the full command set",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "migrationdir",
+			Usage: "override migration folder",
+			Value: database.MigrationDir,
+		},
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:   "status",
+			Usage:  "print each migration's applied or pending state and the current schema version",
+			Action: migrationStatus,
+		},
+		{
+			Name:  "down-to",
+			Usage: "roll back migrations to a specific version",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "args",
+					Usage: "version to roll back to",
+				},
+			},
+			Action: migrationDownTo,
+		},
+	},
+}
+
+var (
+	errInvalidMigrationVersion = errors.New("invalid migration version")
+	errBelowBaselineVersion    = errors.New("cannot roll back below the baseline migration")
+)
+
+func migrationStatus(c *cli.Context) error {
+	err := load(c)
+	if err != nil {
+		return err
+	}
+	return runMigration(dbConn.SQL,
+		repository.GetSQLDialect(),
+		c.String("migrationdir"),
+		"status")
+}
+
+func migrationDownTo(c *cli.Context) error {
+	if !c.IsSet("args") && c.Args().Get(0) == "" {
+		return cli.ShowSubcommandHelp(c)
+	}
+	target := c.String("args")
+	if target == "" {
+		target = c.Args().Get(0)
+	}
+
+	err := load(c)
+	if err != nil {
+		return err
+	}
+	err = runMigration(dbConn.SQL,
+		repository.GetSQLDialect(),
+		c.String("migrationdir"),
+		"down-to",
+		target)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Rolled back to migration version %s\n", target)
+	return nil
+}
+
+// runMigration runs a goose command the same way cmd/dbmigrate does, down-to
+// targets are checked against the known migrations first and status is
+// followed by the current schema version
+func runMigration(db *sql.DB, driver, migrationDir, command string, args ...string) error {
+	if command == "down-to" {
+		if len(args) == 0 {
+			return errInvalidMigrationVersion
+		}
+		err := validateDownTo(driver, migrationDir, args[0])
+		if err != nil {
+			return err
+		}
+	}
+	err := goose.Run(command, db, driver, migrationDir, args...)
+	if err != nil || command != "status" {
+		return err
+	}
+	return goose.Run("version", db, driver, migrationDir)
+}
+
+// validateDownTo ensures the target is a known migration version and not
+// below the first migration
+func validateDownTo(driver, migrationDir, target string) error {
+	version, err := strconv.ParseInt(target, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s %w", target, errInvalidMigrationVersion)
+	}
+	migrations, err := goose.CollectMigrations(migrationDir, driver, 0, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		return fmt.Errorf("no migrations found in %s", migrationDir)
+	}
+	if version < migrations[0].Version {
+		return fmt.Errorf("%d %w %d", version, errBelowBaselineVersion, migrations[0].Version)
+	}
+	for x := range migrations {
+		if migrations[x].Version == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("%d %w", version, errInvalidMigrationVersion)
+}
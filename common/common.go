@@ -26,11 +26,12 @@ var (
 	HTTPUserAgent string
 
 	// ErrNotYetImplemented defines a common error across the code base that
-	// alerts of a function that has not been completed or tied into main code
+	// alerts of a function that has not been completed or tied into main code.
+	// It should only be returned where the API offers the capability
 	ErrNotYetImplemented = errors.New("not yet implemented")
 
 	// ErrFunctionNotSupported defines a standardised error for an unsupported
-	// wrapper function by an API
+	// wrapper function by an API, where the exchange cannot offer it at all
 	ErrFunctionNotSupported = errors.New("unsupported wrapper function")
 )
 
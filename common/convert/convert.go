@@ -1,12 +1,17 @@
 package convert
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
 	"time"
 )
 
+// ErrInvalidNumericField is returned when a named field cannot be parsed as
+// a number
+var ErrInvalidNumericField = errors.New("invalid numeric field")
+
 // FloatFromString format
 func FloatFromString(raw interface{}) (float64, error) {
 	str, ok := raw.(string)
@@ -20,6 +25,20 @@ func FloatFromString(raw interface{}) (float64, error) {
 	return flt, nil
 }
 
+// FloatFromField parses a string number from a response field, returning an
+// error naming the field and its raw value on failure
+func FloatFromField(field, raw string) (float64, error) {
+	flt, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %s value %q: %v",
+			ErrInvalidNumericField,
+			field,
+			raw,
+			err)
+	}
+	return flt, nil
+}
+
 // IntFromString format
 func IntFromString(raw interface{}) (int, error) {
 	str, ok := raw.(string)
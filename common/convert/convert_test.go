@@ -1,6 +1,8 @@
 package convert
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -29,6 +31,25 @@ func TestFloatFromString(t *testing.T) {
 	}
 }
 
+func TestFloatFromField(t *testing.T) {
+	t.Parallel()
+	f, err := FloatFromField("price", "1.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != 1.5 {
+		t.Errorf("expected %v, received %v", 1.5, f)
+	}
+
+	_, err = FloatFromField("price", "1.5x")
+	if !errors.Is(err, ErrInvalidNumericField) {
+		t.Fatalf("expected %v, received %v", ErrInvalidNumericField, err)
+	}
+	if !strings.Contains(err.Error(), "price") || !strings.Contains(err.Error(), `"1.5x"`) {
+		t.Errorf("error should name the field and raw value, received %v", err)
+	}
+}
+
 func TestIntFromString(t *testing.T) {
 	t.Parallel()
 	testString := "1337"
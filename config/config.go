@@ -24,6 +24,7 @@ import (
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
 // GetCurrencyConfig returns currency configurations
@@ -1065,6 +1066,8 @@ func (c *Config) CheckExchangeConfigValues() error {
 					log.Warnln(log.ConfigMgr, err.Error())
 				}
 			}
+			withdraw.LoadAddressWhitelist(c.Exchanges[i].Name,
+				c.Exchanges[i].WithdrawalAddressWhitelist)
 			exchanges++
 		}
 	}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Environment variable suffixes which override an exchange's configured API
+// credentials when set. The full variable name is GCT_<EXCHANGE>_<SUFFIX>
+// with the exchange name upper cased and any non alphanumeric characters
+// replaced by underscores e.g. GCT_BTC_MARKETS_API_KEY
+const (
+	EnvAPIKeySuffix      = "API_KEY"
+	EnvAPISecretSuffix   = "API_SECRET"
+	EnvAPIClientIDSuffix = "API_CLIENT_ID"
+)
+
+// ExchangeEnvName returns the environment variable name for an exchange
+// setting suffix
+func ExchangeEnvName(exchangeName, suffix string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, exchangeName)
+	return "GCT_" + name + "_" + suffix
+}
+
+// CredentialsWithEnvironmentOverrides returns the exchange API credentials
+// with any values set in the environment taking precedence over the config
+// file. The config itself is left untouched so secrets are never saved
+func (e *ExchangeConfig) CredentialsWithEnvironmentOverrides() APICredentialsConfig {
+	creds := e.API.Credentials
+	if v, ok := os.LookupEnv(ExchangeEnvName(e.Name, EnvAPIKeySuffix)); ok {
+		creds.Key = v
+	}
+	if v, ok := os.LookupEnv(ExchangeEnvName(e.Name, EnvAPISecretSuffix)); ok {
+		creds.Secret = v
+	}
+	if v, ok := os.LookupEnv(ExchangeEnvName(e.Name, EnvAPIClientIDSuffix)); ok {
+		creds.ClientID = v
+	}
+	return creds
+}
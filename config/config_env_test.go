@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExchangeEnvName(t *testing.T) {
+	t.Parallel()
+	if v := ExchangeEnvName("BTC Markets", EnvAPIKeySuffix); v != "GCT_BTC_MARKETS_API_KEY" {
+		t.Errorf("expected %v, received %v", "GCT_BTC_MARKETS_API_KEY", v)
+	}
+}
+
+func TestCredentialsWithEnvironmentOverrides(t *testing.T) {
+	e := ExchangeConfig{Name: "Bitstamp"}
+	e.API.Credentials.Key = "filekey"
+	e.API.Credentials.Secret = "filesecret"
+	e.API.Credentials.ClientID = "fileclient"
+
+	err := os.Setenv("GCT_BITSTAMP_API_KEY", "envkey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv("GCT_BITSTAMP_API_KEY")
+	err = os.Setenv("GCT_BITSTAMP_API_SECRET", "envsecret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv("GCT_BITSTAMP_API_SECRET")
+
+	creds := e.CredentialsWithEnvironmentOverrides()
+	if creds.Key != "envkey" || creds.Secret != "envsecret" {
+		t.Errorf("expected environment credentials, received %+v", creds)
+	}
+	if creds.ClientID != "fileclient" {
+		t.Errorf("expected %v, received %v", "fileclient", creds.ClientID)
+	}
+	if e.API.Credentials.Key != "filekey" {
+		t.Error("expected config credentials to be left untouched")
+	}
+}
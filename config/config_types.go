@@ -117,17 +117,21 @@ type ExchangeConfig struct {
 	UseSandbox                    bool                   `json:"useSandbox,omitempty"`
 	HTTPTimeout                   time.Duration          `json:"httpTimeout"`
 	HTTPUserAgent                 string                 `json:"httpUserAgent,omitempty"`
+	HTTPSlowRequestThreshold      time.Duration          `json:"httpSlowRequestThreshold,omitempty"`
 	HTTPDebugging                 bool                   `json:"httpDebugging,omitempty"`
 	WebsocketResponseCheckTimeout time.Duration          `json:"websocketResponseCheckTimeout"`
 	WebsocketResponseMaxLimit     time.Duration          `json:"websocketResponseMaxLimit"`
 	WebsocketTrafficTimeout       time.Duration          `json:"websocketTrafficTimeout"`
 	WebsocketOrderbookBufferLimit int                    `json:"websocketOrderbookBufferLimit"`
+	WebsocketSubscriptionLimit    int                    `json:"websocketSubscriptionLimit,omitempty"`
+	WebsocketPingInterval         time.Duration          `json:"websocketPingInterval,omitempty"`
 	ProxyAddress                  string                 `json:"proxyAddress,omitempty"`
 	BaseCurrencies                currency.Currencies    `json:"baseCurrencies"`
 	CurrencyPairs                 *currency.PairsManager `json:"currencyPairs"`
 	API                           APIConfig              `json:"api"`
 	Features                      *FeaturesConfig        `json:"features"`
 	BankAccounts                  []banking.Account      `json:"bankAccounts,omitempty"`
+	WithdrawalAddressWhitelist    map[string][]string    `json:"withdrawalAddressWhitelist,omitempty"`
 
 	// Deprecated settings which will be removed in a future update
 	AvailablePairs                   *currency.Pairs      `json:"availablePairs,omitempty"`
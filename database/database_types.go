@@ -23,6 +23,8 @@ type Config struct {
 	Enabled                   bool   `json:"enabled"`
 	Verbose                   bool   `json:"verbose"`
 	Driver                    string `json:"driver"`
+	CandlePrecision           int    `json:"candlePrecision,omitempty"`
+	LogCandleConflicts        bool   `json:"logCandleConflicts,omitempty"`
 	drivers.ConnectionDetails `json:"connectionDetails"`
 }
 
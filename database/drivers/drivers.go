@@ -0,0 +1,47 @@
+package drivers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Environment variables which override the configured connection details
+// when set, allowing secrets to be supplied without writing them to the
+// config file
+const (
+	EnvHost     = "GCT_DATABASE_HOST"
+	EnvPort     = "GCT_DATABASE_PORT"
+	EnvUsername = "GCT_DATABASE_USERNAME"
+	EnvPassword = "GCT_DATABASE_PASSWORD"
+	EnvDatabase = "GCT_DATABASE_NAME"
+	EnvSSLMode  = "GCT_DATABASE_SSLMODE"
+)
+
+// WithEnvironmentOverrides returns a copy of the connection details with any
+// values set in the environment taking precedence over the configured values
+func (c ConnectionDetails) WithEnvironmentOverrides() (ConnectionDetails, error) {
+	if v, ok := os.LookupEnv(EnvHost); ok {
+		c.Host = v
+	}
+	if v, ok := os.LookupEnv(EnvPort); ok {
+		port, err := strconv.ParseUint(v, 10, 16)
+		if err != nil {
+			return c, fmt.Errorf("invalid %s value %q: %w", EnvPort, v, err)
+		}
+		c.Port = uint16(port)
+	}
+	if v, ok := os.LookupEnv(EnvUsername); ok {
+		c.Username = v
+	}
+	if v, ok := os.LookupEnv(EnvPassword); ok {
+		c.Password = v
+	}
+	if v, ok := os.LookupEnv(EnvDatabase); ok {
+		c.Database = v
+	}
+	if v, ok := os.LookupEnv(EnvSSLMode); ok {
+		c.SSLMode = v
+	}
+	return c, nil
+}
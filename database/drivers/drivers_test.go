@@ -0,0 +1,49 @@
+package drivers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWithEnvironmentOverrides(t *testing.T) {
+	c := ConnectionDetails{
+		Host:     "localhost",
+		Port:     5432,
+		Username: "gct",
+		Password: "filepassword",
+		Database: "gct",
+	}
+	err := os.Setenv(EnvPassword, "envpassword")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv(EnvPassword)
+	err = os.Setenv(EnvPort, "6543")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv(EnvPort)
+
+	o, err := c.WithEnvironmentOverrides()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Password != "envpassword" || o.Port != 6543 {
+		t.Errorf("expected environment values, received %+v", o)
+	}
+	if o.Host != "localhost" || o.Username != "gct" || o.Database != "gct" {
+		t.Errorf("expected config values to be kept, received %+v", o)
+	}
+	if c.Password != "filepassword" {
+		t.Error("expected original connection details to be left untouched")
+	}
+
+	err = os.Setenv(EnvPort, "lol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.WithEnvironmentOverrides()
+	if err == nil {
+		t.Error("expected error for invalid port")
+	}
+}
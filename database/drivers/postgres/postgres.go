@@ -16,13 +16,18 @@ func Connect() (*database.Instance, error) {
 		database.DB.Config.SSLMode = "disable"
 	}
 
+	details, err := database.DB.Config.ConnectionDetails.WithEnvironmentOverrides()
+	if err != nil {
+		return nil, err
+	}
+
 	configDSN := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		database.DB.Config.Username,
-		database.DB.Config.Password,
-		database.DB.Config.Host,
-		database.DB.Config.Port,
-		database.DB.Config.Database,
-		database.DB.Config.SSLMode)
+		details.Username,
+		details.Password,
+		details.Host,
+		details.Port,
+		details.Database,
+		details.SSLMode)
 
 	db, err := sql.Open(database.DBPostgreSQL, configDSN)
 	if err != nil {
@@ -11,11 +11,16 @@ import (
 
 // Connect opens a connection to sqlite database and returns a pointer to database.DB
 func Connect() (*database.Instance, error) {
-	if database.DB.Config.Database == "" {
+	details, err := database.DB.Config.ConnectionDetails.WithEnvironmentOverrides()
+	if err != nil {
+		return nil, err
+	}
+
+	if details.Database == "" {
 		return nil, database.ErrNoDatabaseProvided
 	}
 
-	databaseFullLocation := filepath.Join(database.DB.DataPath, database.DB.Config.Database)
+	databaseFullLocation := filepath.Join(database.DB.DataPath, details.Database)
 
 	dbConn, err := sql.Open("sqlite3", databaseFullLocation)
 	if err != nil {
@@ -0,0 +1,15 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE "account_snapshot" (
+    id text not null primary key,
+    exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    sub_account text NOT NULL,
+    currency text NOT NULL,
+    total REAL NOT NULL,
+    hold REAL NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    unique(timestamp, exchange_name_id, sub_account, currency) ON CONFLICT IGNORE
+);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE "account_snapshot";
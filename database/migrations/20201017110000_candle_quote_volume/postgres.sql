@@ -0,0 +1,4 @@
+-- +goose Up
+ALTER TABLE candle ADD COLUMN quote_volume DOUBLE PRECISION NOT NULL DEFAULT 0;
+-- +goose Down
+ALTER TABLE candle DROP COLUMN quote_volume;
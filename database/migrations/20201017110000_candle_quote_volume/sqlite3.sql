@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE candle ADD COLUMN quote_volume REAL NOT NULL DEFAULT 0;
+-- +goose Down
+CREATE TABLE "candle_new" (
+                              id	        text not null primary key,
+                              exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+                              Base text NOT NULL,
+                              Quote text NOT NULL,
+                              Interval text NOT NULL,
+                              Timestamp TIMESTAMP NOT NULL,
+                              Open REAL NOT NULL,
+                              High REAL NOT NULL,
+                              Low REAL NOT NULL,
+                              Close REAL NOT NULL,
+                              Volume REAL NOT NULL,
+                              Asset text NOT NULL,
+                              unique(Timestamp, exchange_name_id, Base, Quote, Interval, Asset) ON CONFLICT IGNORE
+);
+INSERT INTO candle_new SELECT id, exchange_name_id, Base, Quote, Interval, Timestamp, Open, High, Low, Close, Volume, Asset FROM candle;
+DROP TABLE candle;
+ALTER TABLE candle_new RENAME TO candle;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE withdrawal_history ADD COLUMN correlation_id text NOT NULL DEFAULT '';
+CREATE INDEX withdrawal_history_correlation_id ON withdrawal_history(correlation_id);
+-- +goose Down
+DROP INDEX IF EXISTS withdrawal_history_correlation_id;
+ALTER TABLE withdrawal_history DROP COLUMN correlation_id;
@@ -12,72 +12,85 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshots)
 	t.Run("AuditEvents", testAuditEvents)
 	t.Run("Exchanges", testExchanges)
 	t.Run("Scripts", testScripts)
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsDelete)
 	t.Run("AuditEvents", testAuditEventsDelete)
 	t.Run("Exchanges", testExchangesDelete)
 	t.Run("Scripts", testScriptsDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsQueryDeleteAll)
 	t.Run("AuditEvents", testAuditEventsQueryDeleteAll)
 	t.Run("Exchanges", testExchangesQueryDeleteAll)
 	t.Run("Scripts", testScriptsQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsSliceDeleteAll)
 	t.Run("AuditEvents", testAuditEventsSliceDeleteAll)
 	t.Run("Exchanges", testExchangesSliceDeleteAll)
 	t.Run("Scripts", testScriptsSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsExists)
 	t.Run("AuditEvents", testAuditEventsExists)
 	t.Run("Exchanges", testExchangesExists)
 	t.Run("Scripts", testScriptsExists)
 }
 
 func TestFind(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsFind)
 	t.Run("AuditEvents", testAuditEventsFind)
 	t.Run("Exchanges", testExchangesFind)
 	t.Run("Scripts", testScriptsFind)
 }
 
 func TestBind(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsBind)
 	t.Run("AuditEvents", testAuditEventsBind)
 	t.Run("Exchanges", testExchangesBind)
 	t.Run("Scripts", testScriptsBind)
 }
 
 func TestOne(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsOne)
 	t.Run("AuditEvents", testAuditEventsOne)
 	t.Run("Exchanges", testExchangesOne)
 	t.Run("Scripts", testScriptsOne)
 }
 
 func TestAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsAll)
 	t.Run("AuditEvents", testAuditEventsAll)
 	t.Run("Exchanges", testExchangesAll)
 	t.Run("Scripts", testScriptsAll)
 }
 
 func TestCount(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsCount)
 	t.Run("AuditEvents", testAuditEventsCount)
 	t.Run("Exchanges", testExchangesCount)
 	t.Run("Scripts", testScriptsCount)
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsHooks)
 	t.Run("AuditEvents", testAuditEventsHooks)
 	t.Run("Exchanges", testExchangesHooks)
 	t.Run("Scripts", testScriptsHooks)
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsInsert)
+	t.Run("AccountSnapshots", testAccountSnapshotsInsertWhitelist)
 	t.Run("AuditEvents", testAuditEventsInsert)
 	t.Run("AuditEvents", testAuditEventsInsertWhitelist)
 	t.Run("Exchanges", testExchangesInsert)
@@ -88,7 +101,9 @@ func TestInsert(t *testing.T) {
 
 // TestToOne tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOne(t *testing.T) {}
+func TestToOne(t *testing.T) {
+	t.Run("AccountSnapshotToExchangeUsingExchangeName", testAccountSnapshotToOneExchangeUsingExchangeName)
+}
 
 // TestOneToOne tests cannot be run in parallel
 // or deadlocks can occur.
@@ -96,11 +111,15 @@ func TestOneToOne(t *testing.T) {}
 
 // TestToMany tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToMany(t *testing.T) {}
+func TestToMany(t *testing.T) {
+	t.Run("ExchangeToExchangeNameAccountSnapshots", testExchangeToManyExchangeNameAccountSnapshots)
+}
 
 // TestToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneSet(t *testing.T) {}
+func TestToOneSet(t *testing.T) {
+	t.Run("AccountSnapshotToExchangeUsingExchangeNameAccountSnapshots", testAccountSnapshotToOneSetOpExchangeUsingExchangeName)
+}
 
 // TestToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
@@ -116,7 +135,9 @@ func TestOneToOneRemove(t *testing.T) {}
 
 // TestToManyAdd tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyAdd(t *testing.T) {}
+func TestToManyAdd(t *testing.T) {
+	t.Run("ExchangeToExchangeNameAccountSnapshots", testExchangeToManyAddOpExchangeNameAccountSnapshots)
+}
 
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
@@ -127,29 +148,34 @@ func TestToManySet(t *testing.T) {}
 func TestToManyRemove(t *testing.T) {}
 
 func TestReload(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsReload)
 	t.Run("AuditEvents", testAuditEventsReload)
 	t.Run("Exchanges", testExchangesReload)
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsReloadAll)
 	t.Run("AuditEvents", testAuditEventsReloadAll)
 	t.Run("Exchanges", testExchangesReloadAll)
 	t.Run("Scripts", testScriptsReloadAll)
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsSelect)
 	t.Run("AuditEvents", testAuditEventsSelect)
 	t.Run("Exchanges", testExchangesSelect)
 	t.Run("Scripts", testScriptsSelect)
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsUpdate)
 	t.Run("AuditEvents", testAuditEventsUpdate)
 	t.Run("Exchanges", testExchangesUpdate)
 	t.Run("Scripts", testScriptsUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsSliceUpdateAll)
 	t.Run("AuditEvents", testAuditEventsSliceUpdateAll)
 	t.Run("Exchanges", testExchangesSliceUpdateAll)
 	t.Run("Scripts", testScriptsSliceUpdateAll)
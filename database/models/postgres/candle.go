@@ -34,6 +34,7 @@ type Candle struct {
 	Low            float64   `boil:"low" json:"low" toml:"low" yaml:"low"`
 	Close          float64   `boil:"close" json:"close" toml:"close" yaml:"close"`
 	Volume         float64   `boil:"volume" json:"volume" toml:"volume" yaml:"volume"`
+	QuoteVolume    float64   `boil:"quote_volume" json:"quote_volume" toml:"quote_volume" yaml:"quote_volume"`
 	Asset          string    `boil:"asset" json:"asset" toml:"asset" yaml:"asset"`
 
 	R *candleR `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -52,6 +53,7 @@ var CandleColumns = struct {
 	Low            string
 	Close          string
 	Volume         string
+	QuoteVolume    string
 	Asset          string
 }{
 	ID:             "id",
@@ -65,26 +67,12 @@ var CandleColumns = struct {
 	Low:            "low",
 	Close:          "close",
 	Volume:         "volume",
+	QuoteVolume:    "quote_volume",
 	Asset:          "asset",
 }
 
 // Generated where
 
-type whereHelperfloat64 struct{ field string }
-
-func (w whereHelperfloat64) EQ(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperfloat64) NEQ(x float64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelperfloat64) LT(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperfloat64) LTE(x float64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelperfloat64) GT(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperfloat64) GTE(x float64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var CandleWhere = struct {
 	ID             whereHelperstring
 	ExchangeNameID whereHelperstring
@@ -97,6 +85,7 @@ var CandleWhere = struct {
 	Low            whereHelperfloat64
 	Close          whereHelperfloat64
 	Volume         whereHelperfloat64
+	QuoteVolume    whereHelperfloat64
 	Asset          whereHelperstring
 }{
 	ID:             whereHelperstring{field: "\"candle\".\"id\""},
@@ -110,6 +99,7 @@ var CandleWhere = struct {
 	Low:            whereHelperfloat64{field: "\"candle\".\"low\""},
 	Close:          whereHelperfloat64{field: "\"candle\".\"close\""},
 	Volume:         whereHelperfloat64{field: "\"candle\".\"volume\""},
+	QuoteVolume:    whereHelperfloat64{field: "\"candle\".\"quote_volume\""},
 	Asset:          whereHelperstring{field: "\"candle\".\"asset\""},
 }
 
@@ -134,9 +124,9 @@ func (*candleR) NewStruct() *candleR {
 type candleL struct{}
 
 var (
-	candleAllColumns            = []string{"id", "exchange_name_id", "base", "quote", "interval", "timestamp", "open", "high", "low", "close", "volume", "asset"}
+	candleAllColumns            = []string{"id", "exchange_name_id", "base", "quote", "interval", "timestamp", "open", "high", "low", "close", "volume", "asset", "quote_volume"}
 	candleColumnsWithoutDefault = []string{"exchange_name_id", "base", "quote", "interval", "timestamp", "open", "high", "low", "close", "volume", "asset"}
-	candleColumnsWithDefault    = []string{"id"}
+	candleColumnsWithDefault    = []string{"id", "quote_volume"}
 	candlePrimaryKeyColumns     = []string{"id"}
 )
 
@@ -6,6 +6,7 @@ package postgres
 import "testing"
 
 func TestUpsert(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsUpsert)
 	t.Run("AuditEvents", testAuditEventsUpsert)
 	t.Run("Exchanges", testExchangesUpsert)
 	t.Run("Scripts", testScriptsUpsert)
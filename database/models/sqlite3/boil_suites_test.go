@@ -12,6 +12,7 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshots)
 	t.Run("AuditEvents", testAuditEvents)
 	t.Run("Candles", testCandles)
 	t.Run("Exchanges", testExchanges)
@@ -23,6 +24,7 @@ func TestParent(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsDelete)
 	t.Run("AuditEvents", testAuditEventsDelete)
 	t.Run("Candles", testCandlesDelete)
 	t.Run("Exchanges", testExchangesDelete)
@@ -34,6 +36,7 @@ func TestDelete(t *testing.T) {
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsQueryDeleteAll)
 	t.Run("AuditEvents", testAuditEventsQueryDeleteAll)
 	t.Run("Candles", testCandlesQueryDeleteAll)
 	t.Run("Exchanges", testExchangesQueryDeleteAll)
@@ -45,6 +48,7 @@ func TestQueryDeleteAll(t *testing.T) {
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsSliceDeleteAll)
 	t.Run("AuditEvents", testAuditEventsSliceDeleteAll)
 	t.Run("Candles", testCandlesSliceDeleteAll)
 	t.Run("Exchanges", testExchangesSliceDeleteAll)
@@ -56,6 +60,7 @@ func TestSliceDeleteAll(t *testing.T) {
 }
 
 func TestExists(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsExists)
 	t.Run("AuditEvents", testAuditEventsExists)
 	t.Run("Candles", testCandlesExists)
 	t.Run("Exchanges", testExchangesExists)
@@ -67,6 +72,7 @@ func TestExists(t *testing.T) {
 }
 
 func TestFind(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsFind)
 	t.Run("AuditEvents", testAuditEventsFind)
 	t.Run("Candles", testCandlesFind)
 	t.Run("Exchanges", testExchangesFind)
@@ -78,6 +84,7 @@ func TestFind(t *testing.T) {
 }
 
 func TestBind(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsBind)
 	t.Run("AuditEvents", testAuditEventsBind)
 	t.Run("Candles", testCandlesBind)
 	t.Run("Exchanges", testExchangesBind)
@@ -89,6 +96,7 @@ func TestBind(t *testing.T) {
 }
 
 func TestOne(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsOne)
 	t.Run("AuditEvents", testAuditEventsOne)
 	t.Run("Candles", testCandlesOne)
 	t.Run("Exchanges", testExchangesOne)
@@ -100,6 +108,7 @@ func TestOne(t *testing.T) {
 }
 
 func TestAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsAll)
 	t.Run("AuditEvents", testAuditEventsAll)
 	t.Run("Candles", testCandlesAll)
 	t.Run("Exchanges", testExchangesAll)
@@ -111,6 +120,7 @@ func TestAll(t *testing.T) {
 }
 
 func TestCount(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsCount)
 	t.Run("AuditEvents", testAuditEventsCount)
 	t.Run("Candles", testCandlesCount)
 	t.Run("Exchanges", testExchangesCount)
@@ -122,6 +132,7 @@ func TestCount(t *testing.T) {
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsHooks)
 	t.Run("AuditEvents", testAuditEventsHooks)
 	t.Run("Candles", testCandlesHooks)
 	t.Run("Exchanges", testExchangesHooks)
@@ -133,6 +144,8 @@ func TestHooks(t *testing.T) {
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsInsert)
+	t.Run("AccountSnapshots", testAccountSnapshotsInsertWhitelist)
 	t.Run("AuditEvents", testAuditEventsInsert)
 	t.Run("AuditEvents", testAuditEventsInsertWhitelist)
 	t.Run("Candles", testCandlesInsert)
@@ -154,6 +167,7 @@ func TestInsert(t *testing.T) {
 // TestToOne tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToOne(t *testing.T) {
+	t.Run("AccountSnapshotToExchangeUsingExchangeName", testAccountSnapshotToOneExchangeUsingExchangeName)
 	t.Run("CandleToExchangeUsingExchangeName", testCandleToOneExchangeUsingExchangeName)
 	t.Run("ScriptExecutionToScriptUsingScript", testScriptExecutionToOneScriptUsingScript)
 	t.Run("WithdrawalCryptoToWithdrawalHistoryUsingWithdrawalHistory", testWithdrawalCryptoToOneWithdrawalHistoryUsingWithdrawalHistory)
@@ -164,6 +178,7 @@ func TestToOne(t *testing.T) {
 // TestOneToOne tests cannot be run in parallel
 // or deadlocks can occur.
 func TestOneToOne(t *testing.T) {
+	t.Run("ExchangeToAccountSnapshotUsingExchangeNameAccountSnapshot", testExchangeOneToOneAccountSnapshotUsingExchangeNameAccountSnapshot)
 	t.Run("ExchangeToCandleUsingExchangeNameCandle", testExchangeOneToOneCandleUsingExchangeNameCandle)
 }
 
@@ -179,6 +194,7 @@ func TestToMany(t *testing.T) {
 // TestToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToOneSet(t *testing.T) {
+	t.Run("AccountSnapshotToExchangeUsingExchangeNameAccountSnapshot", testAccountSnapshotToOneSetOpExchangeUsingExchangeName)
 	t.Run("CandleToExchangeUsingExchangeNameCandle", testCandleToOneSetOpExchangeUsingExchangeName)
 	t.Run("ScriptExecutionToScriptUsingScriptExecutions", testScriptExecutionToOneSetOpScriptUsingScript)
 	t.Run("WithdrawalCryptoToWithdrawalHistoryUsingWithdrawalCryptos", testWithdrawalCryptoToOneSetOpWithdrawalHistoryUsingWithdrawalHistory)
@@ -193,6 +209,7 @@ func TestToOneRemove(t *testing.T) {}
 // TestOneToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
 func TestOneToOneSet(t *testing.T) {
+	t.Run("ExchangeToAccountSnapshotUsingExchangeNameAccountSnapshot", testExchangeOneToOneSetOpAccountSnapshotUsingExchangeNameAccountSnapshot)
 	t.Run("ExchangeToCandleUsingExchangeNameCandle", testExchangeOneToOneSetOpCandleUsingExchangeNameCandle)
 }
 
@@ -218,6 +235,7 @@ func TestToManySet(t *testing.T) {}
 func TestToManyRemove(t *testing.T) {}
 
 func TestReload(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsReload)
 	t.Run("AuditEvents", testAuditEventsReload)
 	t.Run("Candles", testCandlesReload)
 	t.Run("Exchanges", testExchangesReload)
@@ -229,6 +247,7 @@ func TestReload(t *testing.T) {
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsReloadAll)
 	t.Run("AuditEvents", testAuditEventsReloadAll)
 	t.Run("Candles", testCandlesReloadAll)
 	t.Run("Exchanges", testExchangesReloadAll)
@@ -240,6 +259,7 @@ func TestReloadAll(t *testing.T) {
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsSelect)
 	t.Run("AuditEvents", testAuditEventsSelect)
 	t.Run("Candles", testCandlesSelect)
 	t.Run("Exchanges", testExchangesSelect)
@@ -251,6 +271,7 @@ func TestSelect(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsUpdate)
 	t.Run("AuditEvents", testAuditEventsUpdate)
 	t.Run("Candles", testCandlesUpdate)
 	t.Run("Exchanges", testExchangesUpdate)
@@ -262,6 +283,7 @@ func TestUpdate(t *testing.T) {
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("AccountSnapshots", testAccountSnapshotsSliceUpdateAll)
 	t.Run("AuditEvents", testAuditEventsSliceUpdateAll)
 	t.Run("Candles", testCandlesSliceUpdateAll)
 	t.Run("Exchanges", testExchangesSliceUpdateAll)
@@ -4,6 +4,7 @@
 package sqlite3
 
 var TableNames = struct {
+	AccountSnapshot   string
 	AuditEvent        string
 	Candle            string
 	Exchange          string
@@ -13,6 +14,7 @@ var TableNames = struct {
 	WithdrawalFiat    string
 	WithdrawalHistory string
 }{
+	AccountSnapshot:   "account_snapshot",
 	AuditEvent:        "audit_event",
 	Candle:            "candle",
 	Exchange:          "exchange",
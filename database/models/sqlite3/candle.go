@@ -33,6 +33,7 @@ type Candle struct {
 	Low            float64 `boil:"Low" json:"Low" toml:"Low" yaml:"Low"`
 	Close          float64 `boil:"Close" json:"Close" toml:"Close" yaml:"Close"`
 	Volume         float64 `boil:"Volume" json:"Volume" toml:"Volume" yaml:"Volume"`
+	QuoteVolume    float64 `boil:"quote_volume" json:"quote_volume" toml:"quote_volume" yaml:"quote_volume"`
 	Asset          string  `boil:"Asset" json:"Asset" toml:"Asset" yaml:"Asset"`
 
 	R *candleR `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -51,6 +52,7 @@ var CandleColumns = struct {
 	Low            string
 	Close          string
 	Volume         string
+	QuoteVolume    string
 	Asset          string
 }{
 	ID:             "id",
@@ -64,26 +66,12 @@ var CandleColumns = struct {
 	Low:            "Low",
 	Close:          "Close",
 	Volume:         "Volume",
+	QuoteVolume:    "quote_volume",
 	Asset:          "Asset",
 }
 
 // Generated where
 
-type whereHelperfloat64 struct{ field string }
-
-func (w whereHelperfloat64) EQ(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperfloat64) NEQ(x float64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelperfloat64) LT(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperfloat64) LTE(x float64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelperfloat64) GT(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperfloat64) GTE(x float64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var CandleWhere = struct {
 	ID             whereHelperstring
 	ExchangeNameID whereHelperstring
@@ -96,6 +84,7 @@ var CandleWhere = struct {
 	Low            whereHelperfloat64
 	Close          whereHelperfloat64
 	Volume         whereHelperfloat64
+	QuoteVolume    whereHelperfloat64
 	Asset          whereHelperstring
 }{
 	ID:             whereHelperstring{field: "\"candle\".\"id\""},
@@ -109,6 +98,7 @@ var CandleWhere = struct {
 	Low:            whereHelperfloat64{field: "\"candle\".\"Low\""},
 	Close:          whereHelperfloat64{field: "\"candle\".\"Close\""},
 	Volume:         whereHelperfloat64{field: "\"candle\".\"Volume\""},
+	QuoteVolume:    whereHelperfloat64{field: "\"candle\".\"quote_volume\""},
 	Asset:          whereHelperstring{field: "\"candle\".\"Asset\""},
 }
 
@@ -133,9 +123,9 @@ func (*candleR) NewStruct() *candleR {
 type candleL struct{}
 
 var (
-	candleAllColumns            = []string{"id", "exchange_name_id", "Base", "Quote", "Interval", "Timestamp", "Open", "High", "Low", "Close", "Volume", "Asset"}
+	candleAllColumns            = []string{"id", "exchange_name_id", "Base", "Quote", "Interval", "Timestamp", "Open", "High", "Low", "Close", "Volume", "Asset", "quote_volume"}
 	candleColumnsWithoutDefault = []string{"id", "exchange_name_id", "Base", "Quote", "Interval", "Timestamp", "Open", "High", "Low", "Close", "Volume", "Asset"}
-	candleColumnsWithDefault    = []string{}
+	candleColumnsWithDefault    = []string{"quote_volume"}
 	candlePrimaryKeyColumns     = []string{"id"}
 )
 
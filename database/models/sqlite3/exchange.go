@@ -49,15 +49,18 @@ var ExchangeWhere = struct {
 
 // ExchangeRels is where relationship names are stored.
 var ExchangeRels = struct {
+	ExchangeNameAccountSnapshot     string
 	ExchangeNameCandle              string
 	ExchangeNameWithdrawalHistories string
 }{
+	ExchangeNameAccountSnapshot:     "ExchangeNameAccountSnapshot",
 	ExchangeNameCandle:              "ExchangeNameCandle",
 	ExchangeNameWithdrawalHistories: "ExchangeNameWithdrawalHistories",
 }
 
 // exchangeR is where relationships are stored.
 type exchangeR struct {
+	ExchangeNameAccountSnapshot     *AccountSnapshot
 	ExchangeNameCandle              *Candle
 	ExchangeNameWithdrawalHistories WithdrawalHistorySlice
 }
@@ -352,6 +355,20 @@ func (q exchangeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (b
 	return count > 0, nil
 }
 
+// ExchangeNameAccountSnapshot pointed to by the foreign key.
+func (o *Exchange) ExchangeNameAccountSnapshot(mods ...qm.QueryMod) accountSnapshotQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"exchange_name_id\" = ?", o.ID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := AccountSnapshots(queryMods...)
+	queries.SetFrom(query.Query, "\"account_snapshot\"")
+
+	return query
+}
+
 // ExchangeNameCandle pointed to by the foreign key.
 func (o *Exchange) ExchangeNameCandle(mods ...qm.QueryMod) candleQuery {
 	queryMods := []qm.QueryMod{
@@ -387,6 +404,104 @@ func (o *Exchange) ExchangeNameWithdrawalHistories(mods ...qm.QueryMod) withdraw
 	return query
 }
 
+// LoadExchangeNameAccountSnapshot allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (exchangeL) LoadExchangeNameAccountSnapshot(ctx context.Context, e boil.ContextExecutor, singular bool, maybeExchange interface{}, mods queries.Applicator) error {
+	var slice []*Exchange
+	var object *Exchange
+
+	if singular {
+		object = maybeExchange.(*Exchange)
+	} else {
+		slice = *maybeExchange.(*[]*Exchange)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &exchangeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &exchangeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`account_snapshot`), qm.WhereIn(`account_snapshot.exchange_name_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load AccountSnapshot")
+	}
+
+	var resultSlice []*AccountSnapshot
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice AccountSnapshot")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for account_snapshot")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for account_snapshot")
+	}
+
+	if len(exchangeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ExchangeNameAccountSnapshot = foreign
+		if foreign.R == nil {
+			foreign.R = &accountSnapshotR{}
+		}
+		foreign.R.ExchangeName = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ID == foreign.ExchangeNameID {
+				local.R.ExchangeNameAccountSnapshot = foreign
+				if foreign.R == nil {
+					foreign.R = &accountSnapshotR{}
+				}
+				foreign.R.ExchangeName = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadExchangeNameCandle allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
 func (exchangeL) LoadExchangeNameCandle(ctx context.Context, e boil.ContextExecutor, singular bool, maybeExchange interface{}, mods queries.Applicator) error {
@@ -580,6 +695,57 @@ func (exchangeL) LoadExchangeNameWithdrawalHistories(ctx context.Context, e boil
 	return nil
 }
 
+// SetExchangeNameAccountSnapshot of the exchange to the related item.
+// Sets o.R.ExchangeNameAccountSnapshot to related.
+// Adds o to related.R.ExchangeName.
+func (o *Exchange) SetExchangeNameAccountSnapshot(ctx context.Context, exec boil.ContextExecutor, insert bool, related *AccountSnapshot) error {
+	var err error
+
+	if insert {
+		related.ExchangeNameID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"account_snapshot\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, []string{"exchange_name_id"}),
+			strmangle.WhereClause("\"", "\"", 0, accountSnapshotPrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.ID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, updateQuery)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.ExchangeNameID = o.ID
+
+	}
+
+	if o.R == nil {
+		o.R = &exchangeR{
+			ExchangeNameAccountSnapshot: related,
+		}
+	} else {
+		o.R.ExchangeNameAccountSnapshot = related
+	}
+
+	if related.R == nil {
+		related.R = &accountSnapshotR{
+			ExchangeName: o,
+		}
+	} else {
+		related.R.ExchangeName = o
+	}
+	return nil
+}
+
 // SetExchangeNameCandle of the exchange to the related item.
 // Sets o.R.ExchangeNameCandle to related.
 // Adds o to related.R.ExchangeName.
@@ -0,0 +1,271 @@
+package accountsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// Insert writes a batch of account snapshots to the database in a single
+// transaction, snapshots already stored for the same timestamp are ignored
+func Insert(in []Snapshot) (uint64, error) {
+	if database.DB.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+
+	if len(in) == 0 {
+		return 0, errNoSnapshotData
+	}
+
+	// Exchange IDs are resolved before the transaction is opened as the lookup
+	// uses its own connection
+	exchangeIDs := make(map[string]string)
+	for x := range in {
+		if _, ok := exchangeIDs[in[x].Exchange]; ok {
+			continue
+		}
+		exchangeUUID, err := exchange.UUIDByName(in[x].Exchange)
+		if err != nil {
+			return 0, err
+		}
+		exchangeIDs[in[x].Exchange] = exchangeUUID.String()
+	}
+
+	ctx := context.Background()
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var totalInserted uint64
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		totalInserted, err = insertSQLite(ctx, tx, exchangeIDs, in)
+	} else {
+		totalInserted, err = insertPostgresSQL(ctx, tx, exchangeIDs, in)
+	}
+	if err != nil {
+		errRB := tx.Rollback()
+		if errRB != nil {
+			log.Errorln(log.DatabaseMgr, errRB)
+		}
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return totalInserted, nil
+}
+
+// snapshotKey identifies a stored snapshot by its unique columns
+type snapshotKey struct {
+	exchangeID string
+	subAccount string
+	currency   string
+	timestamp  int64
+}
+
+// timeRange returns the earliest and latest snapshot timestamps
+func timeRange(in []Snapshot) (start, end time.Time) {
+	start, end = in[0].Timestamp, in[0].Timestamp
+	for x := range in {
+		if in[x].Timestamp.Before(start) {
+			start = in[x].Timestamp
+		}
+		if in[x].Timestamp.After(end) {
+			end = in[x].Timestamp
+		}
+	}
+	return start.UTC(), end.UTC()
+}
+
+// exchangeIDList returns the resolved exchange IDs for use in a where in
+// clause
+func exchangeIDList(exchangeIDs map[string]string) []interface{} {
+	ids := make([]interface{}, 0, len(exchangeIDs))
+	for _, id := range exchangeIDs {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, exchangeIDs map[string]string, in []Snapshot) (uint64, error) {
+	start, end := timeRange(in)
+	stored, err := modelSQLite.AccountSnapshots(
+		qm.WhereIn("exchange_name_id in ?", exchangeIDList(exchangeIDs)...),
+		qm.Where("timestamp between ? and ?",
+			start.Format(time.RFC3339),
+			end.Format(time.RFC3339))).All(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[snapshotKey]struct{}, len(stored))
+	for x := range stored {
+		t, err := time.Parse(time.RFC3339, stored[x].Timestamp)
+		if err != nil {
+			return 0, err
+		}
+		seen[snapshotKey{stored[x].ExchangeNameID, stored[x].SubAccount, stored[x].Currency, t.Unix()}] = struct{}{}
+	}
+
+	var totalInserted uint64
+	for x := range in {
+		key := snapshotKey{
+			exchangeIDs[in[x].Exchange],
+			in[x].SubAccount,
+			strings.ToUpper(in[x].Currency),
+			in[x].Timestamp.Unix(),
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		tempUUID, err := uuid.NewV4()
+		if err != nil {
+			return 0, err
+		}
+		var tempSnapshot = modelSQLite.AccountSnapshot{
+			ID:             tempUUID.String(),
+			ExchangeNameID: key.exchangeID,
+			SubAccount:     key.subAccount,
+			Currency:       key.currency,
+			Total:          in[x].Total,
+			Hold:           in[x].Hold,
+			Timestamp:      in[x].Timestamp.UTC().Format(time.RFC3339),
+		}
+		err = tempSnapshot.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return 0, err
+		}
+		if totalInserted < math.MaxUint64 {
+			totalInserted++
+		}
+	}
+	return totalInserted, nil
+}
+
+func insertPostgresSQL(ctx context.Context, tx *sql.Tx, exchangeIDs map[string]string, in []Snapshot) (uint64, error) {
+	start, end := timeRange(in)
+	stored, err := modelPSQL.AccountSnapshots(
+		qm.WhereIn("exchange_name_id in ?", exchangeIDList(exchangeIDs)...),
+		qm.Where("timestamp between ? and ?", start, end)).All(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[snapshotKey]struct{}, len(stored))
+	for x := range stored {
+		seen[snapshotKey{stored[x].ExchangeNameID, stored[x].SubAccount, stored[x].Currency, stored[x].Timestamp.Unix()}] = struct{}{}
+	}
+
+	var totalInserted uint64
+	for x := range in {
+		key := snapshotKey{
+			exchangeIDs[in[x].Exchange],
+			in[x].SubAccount,
+			strings.ToUpper(in[x].Currency),
+			in[x].Timestamp.Unix(),
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		tempUUID, err := uuid.NewV4()
+		if err != nil {
+			return 0, err
+		}
+		var tempSnapshot = modelPSQL.AccountSnapshot{
+			ID:             tempUUID.String(),
+			ExchangeNameID: key.exchangeID,
+			SubAccount:     key.subAccount,
+			Currency:       key.currency,
+			Total:          in[x].Total,
+			Hold:           in[x].Hold,
+			Timestamp:      in[x].Timestamp.UTC(),
+		}
+		err = tempSnapshot.Upsert(ctx, tx, false, []string{"timestamp", "exchange_name_id", "sub_account", "currency"}, boil.Infer(), boil.Infer())
+		if err != nil {
+			return 0, err
+		}
+		if totalInserted < math.MaxUint64 {
+			totalInserted++
+		}
+	}
+	return totalInserted, nil
+}
+
+// Series returns the stored account snapshots for an exchange between start
+// and end ordered by timestamp
+func Series(exchangeName string, start, end time.Time) ([]Snapshot, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+
+	if exchangeName == "" || start.IsZero() || end.IsZero() {
+		return nil, errInvalidInput
+	}
+
+	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []qm.QueryMod{
+		qm.Where("exchange_name_id = ?", exchangeUUID.String()),
+		qm.OrderBy("timestamp"),
+	}
+
+	var out []Snapshot
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		queries = append(queries, qm.Where("timestamp between ? and ?",
+			start.UTC().Format(time.RFC3339),
+			end.UTC().Format(time.RFC3339)))
+		ret, err := modelSQLite.AccountSnapshots(queries...).All(context.Background(), database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for x := range ret {
+			t, err := time.Parse(time.RFC3339, ret[x].Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, Snapshot{
+				Exchange:   exchangeName,
+				SubAccount: ret[x].SubAccount,
+				Currency:   ret[x].Currency,
+				Total:      ret[x].Total,
+				Hold:       ret[x].Hold,
+				Timestamp:  t,
+			})
+		}
+	} else {
+		queries = append(queries, qm.Where("timestamp between ? and ?", start.UTC(), end.UTC()))
+		ret, err := modelPSQL.AccountSnapshots(queries...).All(context.Background(), database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for x := range ret {
+			out = append(out, Snapshot{
+				Exchange:   exchangeName,
+				SubAccount: ret[x].SubAccount,
+				Currency:   ret[x].Currency,
+				Total:      ret[x].Total,
+				Hold:       ret[x].Hold,
+				Timestamp:  ret[x].Timestamp.UTC(),
+			})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,150 @@
+package accountsnapshot
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var (
+	verbose       = false
+	testExchanges = []exchange.Details{
+		{
+			Name: "one",
+		},
+	}
+)
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		fmt.Printf("failed to create temp file: %v", err)
+		os.Exit(1)
+	}
+
+	t := m.Run()
+
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+
+	os.Exit(t)
+}
+
+func TestInsertAndSeries(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = exchange.InsertMany(testExchanges)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ts := time.Date(2020, 10, 17, 10, 0, 0, 0, time.UTC)
+			snapshots := []Snapshot{
+				{
+					Exchange:   testExchanges[0].Name,
+					SubAccount: "main",
+					Currency:   "btc",
+					Total:      1.5,
+					Hold:       0.5,
+					Timestamp:  ts,
+				},
+				{
+					Exchange:   testExchanges[0].Name,
+					SubAccount: "main",
+					Currency:   "USD",
+					Total:      1000,
+					Timestamp:  ts,
+				},
+			}
+			inserted, err := Insert(snapshots)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if inserted != 2 {
+				t.Errorf("expected %v, received %v", 2, inserted)
+			}
+
+			// Snapshots already stored for the timestamp are ignored
+			inserted, err = Insert(snapshots[:1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if inserted != 0 {
+				t.Errorf("expected %v, received %v", 0, inserted)
+			}
+
+			ret, err := Series(testExchanges[0].Name, ts.Add(-time.Hour), ts.Add(time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ret) != 2 {
+				t.Fatalf("expected %v, received %v", 2, len(ret))
+			}
+			for i := range ret {
+				if ret[i].Currency == "BTC" {
+					if ret[i].Total != 1.5 || ret[i].Hold != 0.5 ||
+						ret[i].SubAccount != "main" || !ret[i].Timestamp.Equal(ts) {
+						t.Errorf("unexpected snapshot %+v", ret[i])
+					}
+				}
+			}
+
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestInsertNoData(t *testing.T) {
+	database.DB.SQL = nil
+	_, err := Insert(nil)
+	if !errors.Is(err, database.ErrDatabaseSupportDisabled) {
+		t.Errorf("expected %v, received %v", database.ErrDatabaseSupportDisabled, err)
+	}
+}
@@ -0,0 +1,22 @@
+package accountsnapshot
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errNoSnapshotData = errors.New("no account snapshot data provided")
+	errInvalidInput   = errors.New("exchange name, start & end cannot be empty")
+)
+
+// Snapshot holds a currency balance for an exchange sub account at a point
+// in time
+type Snapshot struct {
+	Exchange   string
+	SubAccount string
+	Currency   string
+	Total      float64
+	Hold       float64
+	Timestamp  time.Time
+}
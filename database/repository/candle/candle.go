@@ -10,6 +10,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -54,12 +55,13 @@ func Series(exchangeName, base, quote string, interval int64, asset string, star
 				return out, errT
 			}
 			out.Candles = append(out.Candles, Candle{
-				Timestamp: t,
-				Open:      retCandle[x].Open,
-				High:      retCandle[x].High,
-				Low:       retCandle[x].Low,
-				Close:     retCandle[x].Close,
-				Volume:    retCandle[x].Volume,
+				Timestamp:   t,
+				Open:        retCandle[x].Open,
+				High:        retCandle[x].High,
+				Low:         retCandle[x].Low,
+				Close:       retCandle[x].Close,
+				Volume:      retCandle[x].Volume,
+				QuoteVolume: retCandle[x].QuoteVolume,
 			})
 		}
 	} else {
@@ -70,12 +72,13 @@ func Series(exchangeName, base, quote string, interval int64, asset string, star
 
 		for x := range retCandle {
 			out.Candles = append(out.Candles, Candle{
-				Timestamp: retCandle[x].Timestamp,
-				Open:      retCandle[x].Open,
-				High:      retCandle[x].High,
-				Low:       retCandle[x].Low,
-				Close:     retCandle[x].Close,
-				Volume:    retCandle[x].Volume,
+				Timestamp:   retCandle[x].Timestamp,
+				Open:        retCandle[x].Open,
+				High:        retCandle[x].High,
+				Low:         retCandle[x].Low,
+				Close:       retCandle[x].Close,
+				Volume:      retCandle[x].Volume,
+				QuoteVolume: retCandle[x].QuoteVolume,
 			})
 		}
 	}
@@ -91,6 +94,57 @@ func Series(exchangeName, base, quote string, interval int64, asset string, star
 	return out, err
 }
 
+// Exists returns true when every candle opening within [start, end) on the
+// interval is stored, allowing already stored ranges to be skipped
+func Exists(exchangeName, base, quote string, interval int64, asset string, start, end time.Time) (bool, error) {
+	if exchangeName == "" || base == "" || quote == "" || asset == "" || interval <= 0 || !start.Before(end) {
+		return false, errInvalidInput
+	}
+	if database.DB.SQL == nil {
+		return false, database.ErrDatabaseSupportDisabled
+	}
+
+	step := time.Duration(interval) * time.Second
+	first := start.Truncate(step)
+	if first.Before(start) {
+		first = first.Add(step)
+	}
+	if !first.Before(end) {
+		return true, nil
+	}
+	expected := int64((end.Sub(first) + step - 1) / step)
+
+	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return false, err
+	}
+	queries := []qm.QueryMod{
+		qm.Where("exchange_name_id = ?", exchangeUUID.String()),
+		qm.Where("base = ?", strings.ToUpper(base)),
+		qm.Where("quote = ?", strings.ToUpper(quote)),
+		qm.Where("asset = ?", asset),
+	}
+
+	var stored int64
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		queries = append(queries,
+			qm.Where("interval = ?", strconv.FormatInt(interval, 10)),
+			qm.Where("timestamp >= ? and timestamp < ?",
+				first.UTC().Format(time.RFC3339),
+				end.UTC().Format(time.RFC3339)))
+		stored, err = modelSQLite.Candles(queries...).Count(context.Background(), database.DB.SQL)
+	} else {
+		queries = append(queries,
+			qm.Where("interval = ?", interval),
+			qm.Where("timestamp >= ? and timestamp < ?", first.UTC(), end.UTC()))
+		stored, err = modelPSQL.Candles(queries...).Count(context.Background(), database.DB.SQL)
+	}
+	if err != nil {
+		return false, err
+	}
+	return stored >= expected, nil
+}
+
 // Insert series of candles
 func Insert(in *Item) (uint64, error) {
 	if database.DB.SQL == nil {
@@ -107,6 +161,23 @@ func Insert(in *Item) (uint64, error) {
 		return 0, err
 	}
 
+	if atomic.LoadInt32(&conflictLogging) == 1 {
+		var conflicts []conflict
+		conflicts, err = findConflicts(ctx, tx, in)
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorln(log.DatabaseMgr, errRB)
+			}
+			return 0, err
+		}
+		replaced := repository.GetSQLDialect() != database.DBSQLite3
+		for x := range conflicts {
+			conflicts[x].Replaced = replaced
+			logConflict(in, &conflicts[x])
+		}
+	}
+
 	var totalInserted uint64
 	if repository.GetSQLDialect() == database.DBSQLite3 {
 		totalInserted, err = insertSQLite(ctx, tx, in)
@@ -128,6 +199,124 @@ func Insert(in *Item) (uint64, error) {
 	return totalInserted, nil
 }
 
+// SetConflictLogging enables or disables logging of stored candles whose
+// values differ from a candle inserted under the same key, such as a partial
+// candle later restated. The log states whether the stored candle was
+// replaced, as on PostgreSQL, or kept, as on SQLite which ignores duplicates.
+// Disabled by default, candles are then upserted silently
+func SetConflictLogging(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&conflictLogging, v)
+}
+
+// findConflicts returns the stored candles which share a key with, but differ
+// in value from, the candles being inserted
+func findConflicts(ctx context.Context, tx *sql.Tx, in *Item) ([]conflict, error) {
+	if len(in.Candles) == 0 {
+		return nil, nil
+	}
+	start, end := in.Candles[0].Timestamp, in.Candles[0].Timestamp
+	for x := range in.Candles {
+		if in.Candles[x].Timestamp.Before(start) {
+			start = in.Candles[x].Timestamp
+		}
+		if in.Candles[x].Timestamp.After(end) {
+			end = in.Candles[x].Timestamp
+		}
+	}
+
+	queries := []qm.QueryMod{
+		qm.Where("exchange_name_id = ?", in.ExchangeID),
+		qm.Where("base = ?", strings.ToUpper(in.Base)),
+		qm.Where("quote = ?", strings.ToUpper(in.Quote)),
+		qm.Where("asset = ?", in.Asset),
+	}
+
+	stored := make(map[int64]Candle)
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		queries = append(queries,
+			qm.Where("interval = ?", strconv.FormatInt(in.Interval, 10)),
+			qm.Where("timestamp between ? and ?",
+				start.UTC().Format(time.RFC3339),
+				end.UTC().Format(time.RFC3339)))
+		ret, err := modelSQLite.Candles(queries...).All(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		for x := range ret {
+			t, err := time.Parse(time.RFC3339, ret[x].Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			stored[t.Unix()] = Candle{
+				Timestamp:   t,
+				Open:        ret[x].Open,
+				High:        ret[x].High,
+				Low:         ret[x].Low,
+				Close:       ret[x].Close,
+				Volume:      ret[x].Volume,
+				QuoteVolume: ret[x].QuoteVolume,
+			}
+		}
+	} else {
+		queries = append(queries,
+			qm.Where("interval = ?", in.Interval),
+			qm.Where("timestamp between ? and ?", start.UTC(), end.UTC()))
+		ret, err := modelPSQL.Candles(queries...).All(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		for x := range ret {
+			stored[ret[x].Timestamp.Unix()] = Candle{
+				Timestamp:   ret[x].Timestamp,
+				Open:        ret[x].Open,
+				High:        ret[x].High,
+				Low:         ret[x].Low,
+				Close:       ret[x].Close,
+				Volume:      ret[x].Volume,
+				QuoteVolume: ret[x].QuoteVolume,
+			}
+		}
+	}
+
+	var conflicts []conflict
+	for x := range in.Candles {
+		s, ok := stored[in.Candles[x].Timestamp.Unix()]
+		if !ok {
+			continue
+		}
+		s.Timestamp = in.Candles[x].Timestamp
+		if s == in.Candles[x] {
+			continue
+		}
+		conflicts = append(conflicts, conflict{Stored: s, Received: in.Candles[x]})
+	}
+	return conflicts, nil
+}
+
+// logCandleConflict logs the stored and received values of a conflicting
+// candle and whether the stored candle was replaced or kept
+func logCandleConflict(in *Item, c *conflict) {
+	outcome := "kept"
+	if c.Replaced {
+		outcome = "replaced"
+	}
+	log.Warnf(log.DatabaseMgr,
+		"Candle conflict %s %s-%s %s interval %d at %s: stored O:%v H:%v L:%v C:%v V:%v QV:%v received O:%v H:%v L:%v C:%v V:%v QV:%v, stored candle %s\n",
+		in.ExchangeID,
+		strings.ToUpper(in.Base),
+		strings.ToUpper(in.Quote),
+		in.Asset,
+		in.Interval,
+		c.Received.Timestamp.UTC().Format(time.RFC3339),
+		c.Stored.Open, c.Stored.High, c.Stored.Low, c.Stored.Close, c.Stored.Volume, c.Stored.QuoteVolume,
+		c.Received.Open, c.Received.High, c.Received.Low, c.Received.Close, c.Received.Volume, c.Received.QuoteVolume,
+		outcome)
+}
+
 func insertSQLite(ctx context.Context, tx *sql.Tx, in *Item) (uint64, error) {
 	var totalInserted uint64
 	for x := range in.Candles {
@@ -143,13 +332,16 @@ func insertSQLite(ctx context.Context, tx *sql.Tx, in *Item) (uint64, error) {
 			Low:            in.Candles[x].Low,
 			Close:          in.Candles[x].Close,
 			Volume:         in.Candles[x].Volume,
+			QuoteVolume:    in.Candles[x].QuoteVolume,
 		}
 		tempUUID, err := uuid.NewV4()
 		if err != nil {
 			return 0, err
 		}
 		tempCandle.ID = tempUUID.String()
-		err = tempCandle.Insert(ctx, tx, boil.Infer())
+		// Always insert the quote volume so no default values need to be
+		// read back, as duplicate candles are ignored rather than inserted
+		err = tempCandle.Insert(ctx, tx, boil.Greylist(modelSQLite.CandleColumns.QuoteVolume))
 		if err != nil {
 			return 0, err
 		}
@@ -175,6 +367,7 @@ func insertPostgresSQL(ctx context.Context, tx *sql.Tx, in *Item) (uint64, error
 			Low:            in.Candles[x].Low,
 			Close:          in.Candles[x].Close,
 			Volume:         in.Candles[x].Volume,
+			QuoteVolume:    in.Candles[x].QuoteVolume,
 		}
 		err := tempCandle.Upsert(ctx, tx, true, []string{"timestamp", "exchange_name_id", "base", "quote", "interval", "asset"}, boil.Infer(), boil.Infer())
 		if err != nil {
@@ -115,6 +115,98 @@ func TestInsert(t *testing.T) {
 	}
 }
 
+func TestInsertConflictLogging(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		seedDB func(includeOHLCVData bool) error
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+			seedDB: seedDB,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			seedDB: seedDB,
+		},
+	}
+
+	var logged []conflict
+	logConflict = func(_ *Item, c *conflict) {
+		logged = append(logged, *c)
+	}
+	defer func() {
+		logConflict = logCandleConflict
+		SetConflictLogging(false)
+	}()
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = test.seedDB(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := genOHCLVData()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data.Candles[5].Volume = 1500
+
+			// silent by default
+			logged = nil
+			SetConflictLogging(false)
+			_, err = Insert(&data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(logged) != 0 {
+				t.Errorf("expected %v conflicts logged, received %v", 0, len(logged))
+			}
+
+			SetConflictLogging(true)
+			data.Candles[5].Volume = 2000
+			_, err = Insert(&data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(logged) != 1 {
+				t.Fatalf("expected %v conflicts logged, received %v", 1, len(logged))
+			}
+			if !logged[0].Received.Timestamp.Equal(data.Candles[5].Timestamp) ||
+				logged[0].Received.Volume != 2000 ||
+				logged[0].Stored.Volume == 2000 {
+				t.Errorf("unexpected conflict %+v", logged[0])
+			}
+			// SQLite ignores duplicates so the stored candle is kept
+			if replaced := test.config.Driver != database.DBSQLite3; logged[0].Replaced != replaced {
+				t.Errorf("expected replaced %v, received %v", replaced, logged[0].Replaced)
+			}
+
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func TestInsertFromCSV(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -261,6 +353,78 @@ func TestSeries(t *testing.T) {
 	}
 }
 
+func TestExists(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		seedDB func(includeOHLCVData bool) error
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+			seedDB: seedDB,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			seedDB: seedDB,
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = test.seedDB(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, tt := range []struct {
+				name       string
+				start, end time.Time
+				expected   bool
+			}{
+				{"full", time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC), true},
+				{"unaligned full", time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2019, 12, 31, 12, 0, 0, 0, time.UTC), true},
+				{"partial", time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC), false},
+				{"empty", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), false},
+			} {
+				exists, err := Exists(testExchanges[0].Name, "BTC", "USDT", 86400, "spot", tt.start, tt.end)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if exists != tt.expected {
+					t.Errorf("%s: expected %v, received %v", tt.name, tt.expected, exists)
+				}
+			}
+
+			end := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+			_, err = Exists(testExchanges[0].Name, "BTC", "USDT", 86400, "spot", end.Add(time.Hour), end)
+			if !errors.Is(err, errInvalidInput) {
+				t.Errorf("expected %v, received %v", errInvalidInput, err)
+			}
+
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func seedDB(includeOHLCVData bool) error {
 	err := exchange.InsertMany(testExchanges)
 	if err != nil {
@@ -12,6 +12,11 @@ const (
 var (
 	errInvalidInput = errors.New("exchange, base , quote, asset, interval, start & end cannot be empty")
 	errNoCandleData = errors.New("no candle data provided")
+
+	// conflictLogging is set when stored candles which differ from an
+	// inserted candle under the same key should be logged
+	conflictLogging int32
+	logConflict     = logCandleConflict
 )
 
 // Item generic candle holder for modelPSQL & modelSQLite
@@ -27,10 +32,20 @@ type Item struct {
 
 // Candle holds each interval
 type Candle struct {
-	Timestamp time.Time
-	Open      float64
-	High      float64
-	Low       float64
-	Close     float64
-	Volume    float64
+	Timestamp   time.Time
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64
+	QuoteVolume float64
+}
+
+// conflict holds a stored candle alongside an inserted candle with the same
+// key but different values. Replaced reports whether the stored candle is
+// overwritten, SQLite keeps the stored candle and ignores the duplicate
+type conflict struct {
+	Stored   Candle
+	Received Candle
+	Replaced bool
 }
@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/gofrs/uuid"
-	"github.com/thrasher-corp/gocryptotrader/common/cache"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
 	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
@@ -174,28 +173,53 @@ func insertPostgresql(ctx context.Context, tx *sql.Tx, in []Details) (err error)
 	return nil
 }
 
-// UUIDByName returns UUID of exchange
+// UUIDByName returns UUID of exchange, concurrent lookups of the same
+// uncached exchange share a single database query
 func UUIDByName(exchange string) (uuid.UUID, error) {
 	exchange = strings.ToLower(exchange)
 	v := exchangeCache.Get(exchange)
 	if v != nil {
 		return v.(uuid.UUID), nil
 	}
-	ret, err := One(exchange)
-	if err != nil {
-		if err != sql.ErrNoRows {
-			return uuid.UUID{}, err
-		}
-		return uuid.UUID{}, ErrNoExchangeFound
-	}
 
-	exchangeCache.Add(exchange, ret.UUID)
-	return ret.UUID, nil
+	lookupMtx.Lock()
+	// the cache may have been populated while waiting for the lock
+	v = exchangeCache.Get(exchange)
+	if v != nil {
+		lookupMtx.Unlock()
+		return v.(uuid.UUID), nil
+	}
+	if l, ok := lookups[exchange]; ok {
+		lookupMtx.Unlock()
+		l.wg.Wait()
+		return l.id, l.err
+	}
+	l := &uuidLookup{}
+	l.wg.Add(1)
+	lookups[exchange] = l
+	lookupMtx.Unlock()
+
+	ret, err := lookupExchange(exchange)
+	switch {
+	case err == sql.ErrNoRows:
+		l.err = ErrNoExchangeFound
+	case err != nil:
+		l.err = err
+	default:
+		l.id = ret.UUID
+		exchangeCache.Add(exchange, ret.UUID)
+	}
+
+	lookupMtx.Lock()
+	delete(lookups, exchange)
+	lookupMtx.Unlock()
+	l.wg.Done()
+	return l.id, l.err
 }
 
 // ResetExchangeCache reinitialise cache to blank state used to clear cache for testing
 func ResetExchangeCache() {
-	exchangeCache = cache.New(10)
+	exchangeCache.Clear()
 }
 
 // LoadCSV loads & parses a CSV list of exchanges
@@ -1,12 +1,18 @@
 package exchange
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
@@ -182,3 +188,53 @@ func TestLoadCSV(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestUUIDByNameConcurrent(t *testing.T) {
+	ResetExchangeCache()
+	defer ResetExchangeCache()
+
+	expected, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var queries int32
+	lookupExchange = func(in string) (Details, error) {
+		atomic.AddInt32(&queries, 1)
+		time.Sleep(time.Millisecond * 50)
+		return Details{UUID: expected, Name: in}, nil
+	}
+	defer func() { lookupExchange = One }()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := UUIDByName("Hammered")
+			if err != nil {
+				errs <- err
+				return
+			}
+			if id != expected {
+				errs <- fmt.Errorf("expected %v, received %v", expected, id)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if queries != 1 {
+		t.Errorf("expected a single lookup, received %v", queries)
+	}
+
+	lookupExchange = func(string) (Details, error) {
+		return Details{}, sql.ErrNoRows
+	}
+	_, err = UUIDByName("missing")
+	if !errors.Is(err, ErrNoExchangeFound) {
+		t.Errorf("expected %v, received %v", ErrNoExchangeFound, err)
+	}
+}
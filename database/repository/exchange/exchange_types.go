@@ -2,6 +2,7 @@ package exchange
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common/cache"
@@ -10,8 +11,21 @@ import (
 var (
 	exchangeCache      = cache.New(10)
 	ErrNoExchangeFound = errors.New("exchange not found")
+
+	// lookupExchange resolves an exchange on a cache miss
+	lookupExchange = One
+	lookupMtx      sync.Mutex
+	lookups        = make(map[string]*uuidLookup)
 )
 
+// uuidLookup is an in flight exchange UUID lookup shared by concurrent
+// callers requesting the same exchange
+type uuidLookup struct {
+	wg  sync.WaitGroup
+	id  uuid.UUID
+	err error
+}
+
 // Details holds exchange information such as Name
 type Details struct {
 	UUID uuid.UUID
@@ -23,8 +23,14 @@ import (
 var (
 	// ErrNoResults is the error returned if no results are found
 	ErrNoResults = errors.New("no results found")
+
+	errInvalidPageSize = errors.New("page size must be greater than zero")
 )
 
+// sqliteTimeFormat matches the format of SQLite's CURRENT_TIMESTAMP so stored
+// and queried times compare correctly as text
+const sqliteTimeFormat = "2006-01-02 15:04:05"
+
 // Event stores Withdrawal Response details in database
 func Event(res *withdraw.Response) {
 	if database.DB.SQL == nil {
@@ -40,6 +46,14 @@ func Event(res *withdraw.Response) {
 		return
 	}
 
+	if res.CorrelationID == uuid.Nil {
+		res.CorrelationID, err = uuid.NewV4()
+		if err != nil {
+			log.Errorf(log.DatabaseMgr, "Failed to generate correlation ID: %v", err)
+			return
+		}
+	}
+
 	res.Exchange.Name = exchangeUUID.String()
 	tx, err := database.DB.SQL.BeginTx(ctx, nil)
 	if err != nil {
@@ -76,6 +90,9 @@ func addPSQLEvent(ctx context.Context, tx *sql.Tx, res *withdraw.Response) (err
 		Currency:       res.RequestDetails.Currency.String(),
 		Amount:         res.RequestDetails.Amount,
 		WithdrawType:   int(res.RequestDetails.Type),
+		CorrelationID:  res.CorrelationID.String(),
+		CreatedAt:      res.CreatedAt.UTC(),
+		UpdatedAt:      res.UpdatedAt.UTC(),
 	}
 
 	if res.RequestDetails.Description != "" {
@@ -157,6 +174,14 @@ func addSQLiteEvent(ctx context.Context, tx *sql.Tx, res *withdraw.Response) (er
 		Currency:       res.RequestDetails.Currency.String(),
 		Amount:         res.RequestDetails.Amount,
 		WithdrawType:   int64(res.RequestDetails.Type),
+		CorrelationID:  res.CorrelationID.String(),
+	}
+
+	if !res.CreatedAt.IsZero() {
+		tempEvent.CreatedAt = res.CreatedAt.UTC().Format(sqliteTimeFormat)
+	}
+	if !res.UpdatedAt.IsZero() {
+		tempEvent.UpdatedAt = res.UpdatedAt.UTC().Format(sqliteTimeFormat)
 	}
 
 	if res.RequestDetails.Description != "" {
@@ -229,6 +254,50 @@ func GetEventByUUID(id string) (*withdraw.Response, error) {
 	return resp[0], nil
 }
 
+// GetEventByCorrelationID returns requested withdraw information by its
+// locally generated correlation ID
+func GetEventByCorrelationID(id string) (*withdraw.Response, error) {
+	resp, err := getByColumns(generateWhereQuery([]string{"correlation_id"}, []string{id}, 1))
+	if err != nil {
+		return nil, err
+	}
+	return resp[0], nil
+}
+
+// UpdateEventStatus updates the exchange status of a stored withdrawal event
+// by its correlation ID
+func UpdateEventStatus(correlationID, status string) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	var rows int64
+	var err error
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		rows, err = modelSQLite.WithdrawalHistories(
+			modelSQLite.WithdrawalHistoryWhere.CorrelationID.EQ(correlationID),
+		).UpdateAll(ctx, database.DB.SQL, modelSQLite.M{
+			modelSQLite.WithdrawalHistoryColumns.Status:    status,
+			modelSQLite.WithdrawalHistoryColumns.UpdatedAt: time.Now().UTC().Format(sqliteTimeFormat),
+		})
+	} else {
+		rows, err = modelPSQL.WithdrawalHistories(
+			modelPSQL.WithdrawalHistoryWhere.CorrelationID.EQ(correlationID),
+		).UpdateAll(ctx, database.DB.SQL, modelPSQL.M{
+			modelPSQL.WithdrawalHistoryColumns.Status:    status,
+			modelPSQL.WithdrawalHistoryColumns.UpdatedAt: time.Now().UTC(),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
 // GetEventsByExchange returns all withdrawal requests by exchange
 func GetEventsByExchange(exchange string, limit int) ([]*withdraw.Response, error) {
 	exch, err := exchangeDB.UUIDByName(exchange)
@@ -239,6 +308,24 @@ func GetEventsByExchange(exchange string, limit int) ([]*withdraw.Response, erro
 	return getByColumns(generateWhereQuery([]string{"exchange_name_id"}, []string{exch.String()}, limit))
 }
 
+// GetEventsByExchangePaged returns a page of withdrawal requests by exchange
+// ordered by creation time, offset skips the number of events already read
+func GetEventsByExchangePaged(exchange string, limit, offset int) ([]*withdraw.Response, error) {
+	if limit <= 0 {
+		return nil, errInvalidPageSize
+	}
+	exch, err := exchangeDB.UUIDByName(exchange)
+	if err != nil {
+		log.Error(log.DatabaseMgr, err)
+		return nil, err
+	}
+	q := generateWhereQuery([]string{"exchange_name_id"}, []string{exch.String()}, limit)
+	if offset > 0 {
+		q = append(q, qm.Offset(offset))
+	}
+	return getByColumns(append(q, qm.OrderBy("created_at, id")))
+}
+
 // GetEventByExchangeID return requested withdraw information by Exchange ID
 func GetEventByExchangeID(exchange, id string) (*withdraw.Response, error) {
 	exch, err := exchangeDB.UUIDByName(exchange)
@@ -253,9 +340,19 @@ func GetEventByExchangeID(exchange, id string) (*withdraw.Response, error) {
 	return resp[0], err
 }
 
-// GetEventsByDate returns requested withdraw information by date range
+// GetEventsByDate returns requested withdraw information by date range,
+// inclusive of both start and end and ordered by creation time
 func GetEventsByDate(exchange string, start, end time.Time, limit int) ([]*withdraw.Response, error) {
-	betweenQuery := generateWhereBetweenQuery("created_at", start, end, limit)
+	var betweenQuery []qm.QueryMod
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		betweenQuery = generateWhereBetweenQuery("created_at",
+			start.UTC().Format(sqliteTimeFormat),
+			end.UTC().Format(sqliteTimeFormat),
+			limit)
+	} else {
+		betweenQuery = generateWhereBetweenQuery("created_at", start.UTC(), end.UTC(), limit)
+	}
+	betweenQuery = append(betweenQuery, qm.OrderBy("created_at, id"))
 	if exchange == "" {
 		return getByColumns(betweenQuery)
 	}
@@ -304,6 +401,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 			tempResp.Exchange = new(withdraw.ExchangeResponse)
 			tempResp.Exchange.ID = v[x].ExchangeID
 			tempResp.Exchange.Status = v[x].Status
+			tempResp.CorrelationID, _ = uuid.FromString(v[x].CorrelationID)
 			tempResp.RequestDetails = new(withdraw.Request)
 			tempResp.RequestDetails = &withdraw.Request{
 				Currency:    currency.NewCode(v[x].Currency),
@@ -378,6 +476,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 			tempResp.Exchange = new(withdraw.ExchangeResponse)
 			tempResp.Exchange.ID = v[x].ExchangeID
 			tempResp.Exchange.Status = v[x].Status
+			tempResp.CorrelationID, _ = uuid.FromString(v[x].CorrelationID)
 			tempResp.RequestDetails = new(withdraw.Request)
 			tempResp.RequestDetails = &withdraw.Request{
 				Currency:    currency.NewCode(v[x].Currency),
@@ -9,9 +9,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
 	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
@@ -75,6 +77,57 @@ func TestWithdraw(t *testing.T) {
 			nil,
 			nil,
 		},
+		{
+			"SQLite-Read",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			readWithdrawHelper,
+			testhelpers.CloseDatabase,
+			nil,
+		},
+		{
+			"Postgres-Read",
+			testhelpers.PostgresTestDatabase,
+			readWithdrawHelper,
+			nil,
+			nil,
+		},
+		{
+			"SQLite-Paged",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			pagedWithdrawHelper,
+			testhelpers.CloseDatabase,
+			nil,
+		},
+		{
+			"Postgres-Paged",
+			testhelpers.PostgresTestDatabase,
+			pagedWithdrawHelper,
+			nil,
+			nil,
+		},
+		{
+			"SQLite-UpdateStatus",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			updateStatusHelper,
+			testhelpers.CloseDatabase,
+			nil,
+		},
+		{
+			"Postgres-UpdateStatus",
+			testhelpers.PostgresTestDatabase,
+			updateStatusHelper,
+			nil,
+			nil,
+		},
 	}
 
 	for _, tests := range testCases {
@@ -179,3 +232,189 @@ func withdrawHelper(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func updateStatusHelper(t *testing.T) {
+	exchange.ResetExchangeCache()
+	resp := &withdraw.Response{
+		Exchange: &withdraw.ExchangeResponse{
+			Name:   testExchanges[0].Name,
+			ID:     "update-status",
+			Status: "pending",
+		},
+		RequestDetails: &withdraw.Request{
+			Exchange: testExchanges[0].Name,
+			Currency: currency.BTC,
+			Amount:   1.0,
+			Type:     withdraw.Crypto,
+			Crypto: &withdraw.CryptoRequest{
+				Address: "update-status",
+			},
+		},
+	}
+	Event(resp)
+	if resp.CorrelationID == uuid.Nil {
+		t.Fatal("expected correlation ID to be generated")
+	}
+
+	err := UpdateEventStatus(resp.CorrelationID.String(), "completed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := GetEventByCorrelationID(resp.CorrelationID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ID != resp.ID {
+		t.Errorf("expected %v, received %v", resp.ID, v.ID)
+	}
+	if v.CorrelationID != resp.CorrelationID {
+		t.Errorf("expected %v, received %v", resp.CorrelationID, v.CorrelationID)
+	}
+	if v.Exchange.Status != "completed" {
+		t.Errorf("expected %v, received %v", "completed", v.Exchange.Status)
+	}
+
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		// updated_at must match the stored created_at format so date range
+		// queries compare them correctly
+		var updatedAt string
+		err = database.DB.SQL.QueryRow("SELECT CAST(updated_at AS TEXT) FROM withdrawal_history WHERE correlation_id = ?",
+			resp.CorrelationID.String()).Scan(&updatedAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = time.Parse(sqliteTimeFormat, updatedAt); err != nil {
+			t.Error(err)
+		}
+	}
+
+	err = UpdateEventStatus(withdraw.DryRunID.String(), "completed")
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("expected %v, received %v", ErrNoResults, err)
+	}
+}
+
+func readWithdrawHelper(t *testing.T) {
+	exchange.ResetExchangeCache()
+	start := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour * 4)
+	codes := []currency.Code{currency.BTC, currency.AUD, currency.LTC}
+	// events are written out of order, the first and last fall outside the
+	// range and two sit exactly on its boundaries
+	offsets := []time.Duration{-time.Second, time.Hour * 4, time.Hour, 0, time.Hour * 2, time.Hour*4 + time.Second}
+	for x := range offsets {
+		test := fmt.Sprintf("read-%v", x)
+		resp := &withdraw.Response{
+			Exchange: &withdraw.ExchangeResponse{
+				Name:   testExchanges[0].Name,
+				ID:     test,
+				Status: test,
+			},
+			RequestDetails: &withdraw.Request{
+				Exchange: testExchanges[0].Name,
+				Currency: codes[x%len(codes)],
+				Amount:   float64(x + 1),
+			},
+			CreatedAt: start.Add(offsets[x]),
+			UpdatedAt: start.Add(offsets[x]),
+		}
+		if codes[x%len(codes)].IsFiatCurrency() {
+			resp.RequestDetails.Type = withdraw.Fiat
+			resp.RequestDetails.Fiat = &withdraw.FiatRequest{Bank: new(banking.Account)}
+		} else {
+			resp.RequestDetails.Type = withdraw.Crypto
+			resp.RequestDetails.Crypto = &withdraw.CryptoRequest{Address: test}
+		}
+		Event(resp)
+		exchange.ResetExchangeCache()
+	}
+
+	v, err := GetEventsByDate(testExchanges[0].Name, start, end, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		id       string
+		currency currency.Code
+		created  time.Time
+	}{
+		{"read-3", currency.BTC, start},
+		{"read-2", currency.LTC, start.Add(time.Hour)},
+		{"read-4", currency.AUD, start.Add(time.Hour * 2)},
+		{"read-1", currency.AUD, end},
+	}
+	if len(v) != len(expected) {
+		t.Fatalf("expected %v events, received %v", len(expected), len(v))
+	}
+	for x := range expected {
+		if v[x].Exchange.ID != expected[x].id {
+			t.Errorf("expected %v, received %v", expected[x].id, v[x].Exchange.ID)
+		}
+		if !v[x].RequestDetails.Currency.Match(expected[x].currency) {
+			t.Errorf("expected %v, received %v", expected[x].currency, v[x].RequestDetails.Currency)
+		}
+		if !v[x].CreatedAt.Equal(expected[x].created) {
+			t.Errorf("expected %v, received %v", expected[x].created, v[x].CreatedAt)
+		}
+	}
+
+	v, err = GetEventsByDate("", start, end, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != 2 || v[0].Exchange.ID != "read-3" || v[1].Exchange.ID != "read-2" {
+		t.Error("expected limit to return the earliest events in range")
+	}
+
+	_, err = GetEventsByDate(testExchanges[0].Name, start.Add(-time.Hour*2), start.Add(-time.Hour), 10)
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("expected %v, received %v", ErrNoResults, err)
+	}
+}
+
+func pagedWithdrawHelper(t *testing.T) {
+	seedWithdrawData()
+
+	all, err := GetEventsByExchange(testExchanges[0].Name, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const pageSize = 7
+	if len(all) <= pageSize {
+		t.Fatalf("expected more than one page of events, received %v", len(all))
+	}
+
+	_, err = GetEventsByExchangePaged(testExchanges[0].Name, 0, pageSize)
+	if !errors.Is(err, errInvalidPageSize) {
+		t.Errorf("expected %v, received %v", errInvalidPageSize, err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var previous time.Time
+	for offset := 0; ; offset += pageSize {
+		page, err := GetEventsByExchangePaged(testExchanges[0].Name, pageSize, offset)
+		if errors.Is(err, ErrNoResults) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) > pageSize {
+			t.Fatalf("expected at most %v events, received %v", pageSize, len(page))
+		}
+		for x := range page {
+			if seen[page[x].ID] {
+				t.Errorf("event %v returned on more than one page", page[x].ID)
+			}
+			seen[page[x].ID] = true
+			if page[x].CreatedAt.Before(previous) {
+				t.Errorf("expected events ordered by creation time, %v before %v", page[x].CreatedAt, previous)
+			}
+			previous = page[x].CreatedAt
+		}
+	}
+	if len(seen) != len(all) {
+		t.Errorf("expected %v events across all pages, received %v", len(all), len(seen))
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database"
 	dbpsql "github.com/thrasher-corp/gocryptotrader/database/drivers/postgres"
 	dbsqlite3 "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/sqlboiler/boil"
 )
@@ -69,6 +71,13 @@ func (a *databaseManager) Start() (err error) {
 			boil.DebugWriter = DBLogger
 		}
 
+		precision := -1
+		if Bot.Config.Database.CandlePrecision > 0 {
+			precision = Bot.Config.Database.CandlePrecision
+		}
+		kline.SetStorePrecision(precision)
+		candle.SetConflictLogging(Bot.Config.Database.LogCandleConflicts)
+
 		go a.run()
 		return nil
 	}
@@ -138,6 +138,7 @@ func validateSettings(b *Engine, s *Settings) {
 	b.Settings.MaxVirtualMachines = s.MaxVirtualMachines
 	b.Settings.EnableDispatcher = s.EnableDispatcher
 	b.Settings.EnablePortfolioManager = s.EnablePortfolioManager
+	b.Settings.EnableAccountSnapshots = s.EnableAccountSnapshots
 	b.Settings.WithdrawCacheSize = s.WithdrawCacheSize
 	if b.Settings.EnablePortfolioManager {
 		if b.Settings.PortfolioManagerDelay != time.Duration(0) && s.PortfolioManagerDelay > 0 {
@@ -213,6 +214,12 @@ func validateSettings(b *Engine, s *Settings) {
 	b.Settings.EnableExchangeHTTPDebugging = s.EnableExchangeHTTPDebugging
 	b.Settings.DisableExchangeAutoPairUpdates = s.DisableExchangeAutoPairUpdates
 	b.Settings.ExchangePurgeCredentials = s.ExchangePurgeCredentials
+	if s.OrderPollInterval > 0 {
+		b.Settings.OrderPollInterval = s.OrderPollInterval
+	} else {
+		b.Settings.OrderPollInterval = DefaultOrderPollInterval
+	}
+	b.Settings.EnableCandleTradeFallback = s.EnableCandleTradeFallback
 	b.Settings.EnableWebsocketRoutine = s.EnableWebsocketRoutine
 
 	// Checks if the flag values are different from the defaults
@@ -265,7 +272,8 @@ func PrintSettings(s *Settings) {
 	gctlog.Debugf(gctlog.Global, "\t Enable all pairs: %v", s.EnableAllPairs)
 	gctlog.Debugf(gctlog.Global, "\t Enable coinmarketcap analaysis: %v", s.EnableCoinmarketcapAnalysis)
 	gctlog.Debugf(gctlog.Global, "\t Enable portfolio manager: %v", s.EnablePortfolioManager)
-	gctlog.Debugf(gctlog.Global, "\t Portfolio manager sleep delay: %v\n", s.PortfolioManagerDelay)
+	gctlog.Debugf(gctlog.Global, "\t Portfolio manager sleep delay: %v", s.PortfolioManagerDelay)
+	gctlog.Debugf(gctlog.Global, "\t Enable account snapshots: %v\n", s.EnableAccountSnapshots)
 	gctlog.Debugf(gctlog.Global, "\t Enable gPRC: %v", s.EnableGRPC)
 	gctlog.Debugf(gctlog.Global, "\t Enable gRPC Proxy: %v", s.EnableGRPCProxy)
 	gctlog.Debugf(gctlog.Global, "\t Enable websocket RPC: %v", s.EnableWebsocketRPC)
@@ -303,6 +311,8 @@ func PrintSettings(s *Settings) {
 	gctlog.Debugf(gctlog.Global, "\t Enable exchange HTTP debugging: %v", s.EnableExchangeHTTPDebugging)
 	gctlog.Debugf(gctlog.Global, "\t Max HTTP request jobs: %v", s.MaxHTTPRequestJobsLimit)
 	gctlog.Debugf(gctlog.Global, "\t HTTP request max retry attempts: %v", s.RequestMaxRetryAttempts)
+	gctlog.Debugf(gctlog.Global, "\t Order poll interval: %v", s.OrderPollInterval)
+	gctlog.Debugf(gctlog.Global, "\t Enable candle trade fallback: %v", s.EnableCandleTradeFallback)
 	gctlog.Debugf(gctlog.Global, "\t HTTP timeout: %v", s.HTTPTimeout)
 	gctlog.Debugf(gctlog.Global, "\t HTTP user agent: %v", s.HTTPUserAgent)
 	gctlog.Debugf(gctlog.Global, "- GCTSCRIPT SETTINGS: ")
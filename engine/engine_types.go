@@ -18,6 +18,7 @@ type Settings struct {
 	EnableCoinmarketcapAnalysis bool
 	EnablePortfolioManager      bool
 	PortfolioManagerDelay       time.Duration
+	EnableAccountSnapshots      bool
 	EnableGRPC                  bool
 	EnableGRPCProxy             bool
 	EnableWebsocketRPC          bool
@@ -60,6 +61,8 @@ type Settings struct {
 	EnableExchangeWebsocketSupport bool
 	MaxHTTPRequestJobsLimit        int
 	RequestMaxRetryAttempts        int
+	OrderPollInterval              time.Duration
+	EnableCandleTradeFallback      bool
 
 	// Global HTTP related settings
 	GlobalHTTPTimeout   time.Duration
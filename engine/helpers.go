@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pquerna/otp/totp"
@@ -25,7 +26,10 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
@@ -33,10 +37,18 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 )
 
+// DefaultCandleFetchWorkers is the number of concurrent candle requests made
+// when backfilling multiple pairs and no worker count is supplied
+const DefaultCandleFetchWorkers = 4
+
 var (
-	errCertExpired     = errors.New("gRPC TLS certificate has expired")
-	errCertDataIsNil   = errors.New("gRPC TLS certificate PEM data is nil")
-	errCertTypeInvalid = errors.New("gRPC TLS certificate type is invalid")
+	errOrderWaitTimeout       = errors.New("timed out waiting for order to reach a terminal state")
+	errCertExpired            = errors.New("gRPC TLS certificate has expired")
+	errCertDataIsNil          = errors.New("gRPC TLS certificate PEM data is nil")
+	errCertTypeInvalid        = errors.New("gRPC TLS certificate type is invalid")
+	errInvalidCandleRange     = errors.New("candle start time must be before end time")
+	errNoCandleData           = errors.New("no candle data returned")
+	errCandleIntervalMismatch = errors.New("candle interval mismatch")
 )
 
 // GetSubsystemsStatus returns the status of various subsystems
@@ -445,6 +457,262 @@ func (bot *Engine) GetSpecificTicker(p currency.Pair, exchangeName string, asset
 	return exch.FetchTicker(p, assetType)
 }
 
+// WaitForOrder polls an exchange for the supplied order until it reaches a
+// terminal state (filled, cancelled, rejected or expired) or the timeout
+// elapses. Requests are sent through the exchange requester so its rate
+// limiter is respected
+func (bot *Engine) WaitForOrder(exchName, orderID string, p currency.Pair, a asset.Item, timeout time.Duration) (order.Detail, error) {
+	exch := bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return order.Detail{}, ErrExchangeNotFound
+	}
+	if orderID == "" {
+		return order.Detail{}, errors.New("order ID cannot be empty")
+	}
+	if !exch.SupportsAsset(a) {
+		return order.Detail{}, fmt.Errorf("%s does not support asset type %s",
+			exchName,
+			a)
+	}
+
+	interval := bot.Settings.OrderPollInterval
+	if interval <= 0 {
+		interval = DefaultOrderPollInterval
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		detail, err := exch.GetOrderInfo(orderID)
+		if err != nil {
+			return detail, err
+		}
+		if isTerminalOrderStatus(detail.Status) {
+			return detail, nil
+		}
+		if !time.Now().Add(interval).Before(deadline) {
+			return detail, fmt.Errorf("%s %s %s order %s: %w",
+				exchName,
+				p,
+				a,
+				orderID,
+				errOrderWaitTimeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// CancelAllOrdersEverywhere cancels open orders for every enabled pair across
+// all of an exchange's asset types, aggregating the cancelled order statuses.
+// Failures for a pair or asset do not stop the remaining cancellations and are
+// returned together. Requests are sent sequentially through the exchange
+// requester so its rate limiter is respected
+func (bot *Engine) CancelAllOrdersEverywhere(exchName string) (order.CancelAllResponse, error) {
+	resp := order.CancelAllResponse{Status: make(map[string]string)}
+	exch := bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return resp, ErrExchangeNotFound
+	}
+
+	var errs common.Errors
+	assets := exch.GetAssetTypes()
+	for x := range assets {
+		pairs, err := exch.GetEnabledPairs(assets[x])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", exchName, assets[x], err))
+			continue
+		}
+		for y := range pairs {
+			cancelled, err := exch.CancelAllOrders(&order.Cancel{
+				Exchange:  exch.GetName(),
+				Pair:      pairs[y],
+				AssetType: assets[x],
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s %s %s: %w", exchName, assets[x], pairs[y], err))
+				continue
+			}
+			for id, status := range cancelled.Status {
+				resp.Status[id] = status
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return resp, errs
+	}
+	return resp, nil
+}
+
+// isTerminalOrderStatus returns whether an order can no longer change state
+func isTerminalOrderStatus(s order.Status) bool {
+	switch s {
+	case order.Filled,
+		order.Cancelled,
+		order.PartiallyCancelled,
+		order.Rejected,
+		order.Expired,
+		order.InsufficientBalance,
+		order.MarketUnavailable:
+		return true
+	}
+	return false
+}
+
+// GetHistoricCandles returns historic candles from an exchange. When the
+// exchange does not support candles and the candle trade fallback setting is
+// enabled, candles are built from the exchange trade history instead
+func (bot *Engine) GetHistoricCandles(exchName string, p currency.Pair, a asset.Item, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	exch := bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return kline.Item{}, ErrExchangeNotFound
+	}
+	candles, err := exch.GetHistoricCandles(p, a, start, end, interval)
+	if err == nil ||
+		!errors.Is(err, common.ErrFunctionNotSupported) ||
+		!bot.Settings.EnableCandleTradeFallback {
+		return candles, err
+	}
+
+	trades, err := exch.GetExchangeHistory(p, a, start, end)
+	if err != nil {
+		return kline.Item{}, err
+	}
+	history := make([]order.TradeHistory, len(trades))
+	for x := range trades {
+		history[x] = order.TradeHistory{
+			Price:     trades[x].Price,
+			Amount:    trades[x].Amount,
+			Exchange:  exch.GetName(),
+			TID:       trades[x].TID,
+			Timestamp: trades[x].Timestamp,
+		}
+	}
+	return kline.CreateKline(history, interval, p, a, exch.GetName())
+}
+
+// FetchAndStoreCandles retrieves historic candles from an exchange and stores
+// them in the database. When dry run is enabled the candles are returned
+// without being stored
+func (bot *Engine) FetchAndStoreCandles(exchName string, p currency.Pair, a asset.Item, interval kline.Interval, start, end time.Time) (*kline.Item, error) {
+	candles, err := bot.fetchCandles(exchName, p, a, interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if bot.Settings.EnableDryRun {
+		return candles, nil
+	}
+	_, err = kline.StoreInDatabase(candles)
+	if err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
+
+// CandleFetchResult holds the outcome of fetching and storing candles for a
+// single pair
+type CandleFetchResult struct {
+	Pair    currency.Pair
+	Candles *kline.Item
+	Err     error
+}
+
+// FetchAndStoreCandlesForPairs retrieves historic candles for each pair
+// concurrently through the exchange requester's worker pool, using at most
+// workers in-flight requests, and stores them in the database. Results are
+// returned in the same order as the supplied pairs with any per-pair error
+// recorded on its result
+func (bot *Engine) FetchAndStoreCandlesForPairs(exchName string, pairs currency.Pairs, a asset.Item, interval kline.Interval, start, end time.Time, workers int) []CandleFetchResult {
+	results := make([]CandleFetchResult, len(pairs))
+	for x := range pairs {
+		results[x].Pair = pairs[x]
+	}
+	exch := bot.GetExchangeByName(exchName)
+	if exch == nil {
+		for x := range results {
+			results[x].Err = ErrExchangeNotFound
+		}
+		return results
+	}
+	if workers <= 0 {
+		workers = DefaultCandleFetchWorkers
+	}
+	// Exchanges without a requester are bounded by workers alone
+	requester := new(request.Requester)
+	if b := exch.GetBase(); b != nil && b.Requester != nil {
+		requester = b.Requester
+	}
+
+	// Storage is serialised so backends such as SQLite, which only allow a
+	// single writer, are not contended by the fetch workers
+	var storeMtx sync.Mutex
+	// Errors are recorded per pair rather than returned so a failing pair
+	// does not stop the remaining pairs being dispatched
+	_ = requester.FanOut(workers, len(pairs), func(x int) error {
+		candles, err := bot.fetchCandles(exchName, pairs[x], a, interval, start, end)
+		if err != nil {
+			results[x].Err = err
+			return nil
+		}
+		if !bot.Settings.EnableDryRun {
+			storeMtx.Lock()
+			_, err = kline.StoreInDatabase(candles)
+			storeMtx.Unlock()
+			if err != nil {
+				results[x].Err = err
+				return nil
+			}
+		}
+		results[x].Candles = candles
+		return nil
+	})
+	return results
+}
+
+// fetchCandles retrieves and validates historic candles from an exchange
+func (bot *Engine) fetchCandles(exchName string, p currency.Pair, a asset.Item, interval kline.Interval, start, end time.Time) (*kline.Item, error) {
+	exch := bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	if !exch.SupportsAsset(a) {
+		return nil, fmt.Errorf("%s does not support asset type %s",
+			exchName,
+			a)
+	}
+	if !start.Before(end) {
+		return nil, errInvalidCandleRange
+	}
+
+	candles, err := exch.GetHistoricCandlesExtended(p, a, start, end, interval)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles.Candles) == 0 {
+		return nil, fmt.Errorf("%s %s %s %w", exchName, p, a, errNoCandleData)
+	}
+	if candles.Exchange == "" {
+		candles.Exchange = exch.GetName()
+	}
+	if candles.Pair.IsEmpty() {
+		candles.Pair = p
+	}
+	if candles.Asset == "" {
+		candles.Asset = a
+	}
+	if candles.Interval == 0 {
+		candles.Interval = interval
+	}
+	if candles.Interval != interval {
+		return nil, fmt.Errorf("%s %s %s %w: requested %s received %s",
+			exchName,
+			p,
+			a,
+			errCandleIntervalMismatch,
+			interval,
+			candles.Interval)
+	}
+	return &candles, nil
+}
+
 // GetCollatedExchangeAccountInfoByCoin collates individual exchange account
 // information and turns into into a map string of
 // exchange.AccountCurrencyInfo
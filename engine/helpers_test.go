@@ -7,10 +7,13 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
+	"io/ioutil"
 	"math/big"
 	"net"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -18,9 +21,18 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	dbexchange "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
@@ -752,6 +764,72 @@ func TestGetCryptocurrenciesByExchange(t *testing.T) {
 	}
 }
 
+const fakeOrderPollExchName = "FakeOrderPollExchange"
+
+// fakeOrderPollExchange returns an active order until GetOrderInfo has been
+// called fillAfter times
+type fakeOrderPollExchange struct {
+	FakePassingExchange
+	calls     int
+	fillAfter int
+}
+
+func (f *fakeOrderPollExchange) GetName() string { return fakeOrderPollExchName }
+
+func (f *fakeOrderPollExchange) GetOrderInfo(orderID string) (order.Detail, error) {
+	f.calls++
+	status := order.Active
+	if f.calls >= f.fillAfter {
+		status = order.Filled
+	}
+	return order.Detail{
+		ID:     orderID,
+		Status: status,
+	}, nil
+}
+
+func TestWaitForOrder(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	p := currency.NewPair(currency.BTC, currency.USD)
+	_, err := bot.WaitForOrder("bruh", "1337", p, asset.Spot, time.Second)
+	if err != ErrExchangeNotFound {
+		t.Fatalf("expected %v, received %v", ErrExchangeNotFound, err)
+	}
+
+	fake := &fakeOrderPollExchange{fillAfter: 3}
+	bot.exchangeManager.add(fake)
+	defer func() {
+		err = bot.exchangeManager.removeExchange(fakeOrderPollExchName)
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	bot.Settings.OrderPollInterval = time.Millisecond
+	_, err = bot.WaitForOrder(fakeOrderPollExchName, "", p, asset.Spot, time.Second)
+	if err == nil {
+		t.Fatal("expected error when order ID is empty")
+	}
+
+	o, err := bot.WaitForOrder(fakeOrderPollExchName, "1337", p, asset.Spot, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != order.Filled {
+		t.Fatalf("expected %v, received %v", order.Filled, o.Status)
+	}
+	if fake.calls != 3 {
+		t.Fatalf("expected 3 calls to GetOrderInfo, received %v", fake.calls)
+	}
+
+	fake.calls = 0
+	fake.fillAfter = 1000
+	_, err = bot.WaitForOrder(fakeOrderPollExchName, "1337", p, asset.Spot, 10*time.Millisecond)
+	if !errors.Is(err, errOrderWaitTimeout) {
+		t.Fatalf("expected %v, received %v", errOrderWaitTimeout, err)
+	}
+}
+
 func TestGetExchangeNames(t *testing.T) {
 	bot := SetupTestHelpers(t)
 	if e := bot.GetExchangeNames(true); len(e) == 0 {
@@ -915,3 +993,357 @@ func TestCheckAndGenCerts(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+type fakeCandleExchange struct {
+	FakePassingExchange
+	candles kline.Item
+}
+
+func (f *fakeCandleExchange) GetName() string { return f.Name }
+
+func (f *fakeCandleExchange) GetHistoricCandlesExtended(_ currency.Pair, _ asset.Item, _, _ time.Time, _ kline.Interval) (kline.Item, error) {
+	return f.candles, nil
+}
+
+func TestFetchAndStoreCandles(t *testing.T) {
+	testhelpers.MigrationDir = filepath.Join("..", "database", "migrations")
+	var err error
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer func() {
+		err = os.RemoveAll(testhelpers.TempDir)
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	dbConn, err := testhelpers.ConnectToDatabase(&database.Config{
+		Driver:            database.DBSQLite3,
+		ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err = testhelpers.CloseDatabase(dbConn)
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	const exchName = "candleexchange"
+	err = dbexchange.InsertMany([]dbexchange.Details{{Name: exchName}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := currency.NewPair(currency.BTC, currency.USD)
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour * 3)
+	bot := new(Engine)
+	bot.exchangeManager.add(&fakeCandleExchange{
+		FakePassingExchange: FakePassingExchange{
+			Base: exchange.Base{Name: exchName},
+		},
+		candles: kline.Item{
+			Candles: []kline.Candle{
+				{Time: start, Open: 1, High: 2, Low: 1, Close: 2, Volume: 1},
+				{Time: start.Add(time.Hour), Open: 2, High: 3, Low: 2, Close: 3, Volume: 1},
+			},
+		},
+	})
+
+	_, err = bot.FetchAndStoreCandles("nonexistent", p, asset.Spot, kline.OneHour, start, end)
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("expected %v, received %v", ErrExchangeNotFound, err)
+	}
+	_, err = bot.FetchAndStoreCandles(exchName, p, asset.Spot, kline.OneHour, end, start)
+	if !errors.Is(err, errInvalidCandleRange) {
+		t.Errorf("expected %v, received %v", errInvalidCandleRange, err)
+	}
+
+	bot.Settings.EnableDryRun = true
+	item, err := bot.FetchAndStoreCandles(exchName, p, asset.Spot, kline.OneHour, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Exchange != exchName || !item.Pair.Equal(p) || item.Interval != kline.OneHour {
+		t.Errorf("unexpected candle item details %+v", item)
+	}
+	stored, err := candle.Series(exchName, "BTC", "USD", int64(kline.OneHour.Duration().Seconds()), asset.Spot.String(), start.AddDate(0, 0, -1), end.AddDate(0, 0, 1))
+	if err == nil && len(stored.Candles) != 0 {
+		t.Error("expected dry run to skip storing candles")
+	}
+
+	bot.Settings.EnableDryRun = false
+	_, err = bot.FetchAndStoreCandles(exchName, p, asset.Spot, kline.OneHour, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err = candle.Series(exchName, "BTC", "USD", int64(kline.OneHour.Duration().Seconds()), asset.Spot.String(), start.AddDate(0, 0, -1), end.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Candles) != 2 {
+		t.Errorf("expected %v, received %v", 2, len(stored.Candles))
+	}
+}
+
+type fakeConcurrentCandleExchange struct {
+	FakePassingExchange
+	inFlight    int32
+	maxInFlight int32
+	calls       int32
+}
+
+func (f *fakeConcurrentCandleExchange) GetName() string { return f.Name }
+
+func (f *fakeConcurrentCandleExchange) GetBase() *exchange.Base { return &f.Base }
+
+func (f *fakeConcurrentCandleExchange) GetHistoricCandlesExtended(p currency.Pair, _ asset.Item, start, _ time.Time, _ kline.Interval) (kline.Item, error) {
+	atomic.AddInt32(&f.calls, 1)
+	current := atomic.AddInt32(&f.inFlight, 1)
+	defer atomic.AddInt32(&f.inFlight, -1)
+	for {
+		highest := atomic.LoadInt32(&f.maxInFlight)
+		if current <= highest || atomic.CompareAndSwapInt32(&f.maxInFlight, highest, current) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond * 20)
+	return kline.Item{
+		Pair: p,
+		Candles: []kline.Candle{
+			{Time: start, Open: 1, High: 2, Low: 1, Close: 2, Volume: 1},
+			{Time: start.Add(time.Hour), Open: 2, High: 3, Low: 2, Close: 3, Volume: 1},
+		},
+	}, nil
+}
+
+func TestFetchAndStoreCandlesForPairs(t *testing.T) {
+	testhelpers.MigrationDir = filepath.Join("..", "database", "migrations")
+	var err error
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer func() {
+		err = os.RemoveAll(testhelpers.TempDir)
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	dbConn, err := testhelpers.ConnectToDatabase(&database.Config{
+		Driver:            database.DBSQLite3,
+		ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err = testhelpers.CloseDatabase(dbConn)
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	const exchName = "concurrentcandleexchange"
+	err = dbexchange.InsertMany([]dbexchange.Details{{Name: exchName}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pairs := currency.Pairs{
+		currency.NewPair(currency.BTC, currency.USD),
+		currency.NewPair(currency.ETH, currency.USD),
+		currency.NewPair(currency.LTC, currency.USD),
+		currency.NewPair(currency.XRP, currency.USD),
+		currency.NewPair(currency.BCH, currency.USD),
+		currency.NewPair(currency.EOS, currency.USD),
+	}
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour * 3)
+	exch := &fakeConcurrentCandleExchange{
+		FakePassingExchange: FakePassingExchange{
+			Base: exchange.Base{
+				Name:      exchName,
+				Requester: request.New(exchName, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout)),
+			},
+		},
+	}
+	bot := new(Engine)
+	bot.exchangeManager.add(exch)
+
+	const workers = 2
+	results := bot.FetchAndStoreCandlesForPairs(exchName, pairs, asset.Spot, kline.OneHour, start, end, workers)
+	if len(results) != len(pairs) {
+		t.Fatalf("expected %v, received %v", len(pairs), len(results))
+	}
+	for x := range results {
+		if results[x].Err != nil {
+			t.Errorf("%s: %v", pairs[x], results[x].Err)
+			continue
+		}
+		if !results[x].Pair.Equal(pairs[x]) {
+			t.Errorf("expected %v, received %v", pairs[x], results[x].Pair)
+		}
+		stored, err := candle.Series(exchName,
+			pairs[x].Base.String(),
+			pairs[x].Quote.String(),
+			int64(kline.OneHour.Duration().Seconds()),
+			asset.Spot.String(),
+			start.AddDate(0, 0, -1),
+			end.AddDate(0, 0, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stored.Candles) != 2 {
+			t.Errorf("%s: expected %v, received %v", pairs[x], 2, len(stored.Candles))
+		}
+	}
+	if calls := atomic.LoadInt32(&exch.calls); calls != int32(len(pairs)) {
+		t.Errorf("expected %v, received %v", len(pairs), calls)
+	}
+	if highest := atomic.LoadInt32(&exch.maxInFlight); highest > workers {
+		t.Errorf("expected at most %v concurrent requests, received %v", workers, highest)
+	}
+
+	results = bot.FetchAndStoreCandlesForPairs("nonexistent", pairs[:1], asset.Spot, kline.OneHour, start, end, 0)
+	if !errors.Is(results[0].Err, ErrExchangeNotFound) {
+		t.Errorf("expected %v, received %v", ErrExchangeNotFound, results[0].Err)
+	}
+}
+
+type fakeTradesOnlyExchange struct {
+	FakePassingExchange
+	trades []exchange.TradeHistory
+}
+
+func (f *fakeTradesOnlyExchange) GetName() string { return f.Name }
+
+func (f *fakeTradesOnlyExchange) GetHistoricCandles(_ currency.Pair, _ asset.Item, _, _ time.Time, _ kline.Interval) (kline.Item, error) {
+	return kline.Item{}, common.ErrFunctionNotSupported
+}
+
+func (f *fakeTradesOnlyExchange) GetExchangeHistory(_ currency.Pair, _ asset.Item, _, _ time.Time) ([]exchange.TradeHistory, error) {
+	return f.trades, nil
+}
+
+func TestGetHistoricCandlesTradeFallback(t *testing.T) {
+	t.Parallel()
+	const exchName = "tradesonly"
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	bot := new(Engine)
+	bot.exchangeManager.add(&fakeTradesOnlyExchange{
+		FakePassingExchange: FakePassingExchange{
+			Base: exchange.Base{Name: exchName},
+		},
+		trades: []exchange.TradeHistory{
+			{Timestamp: start.Add(time.Minute * 65), Price: 12, Amount: 1},
+			{Timestamp: start.Add(time.Minute), Price: 10, Amount: 1},
+			{Timestamp: start.Add(time.Minute * 30), Price: 15, Amount: 2},
+			{Timestamp: start.Add(time.Minute * 70), Price: 11, Amount: 3},
+		},
+	})
+	p := currency.NewPair(currency.BTC, currency.USD)
+
+	_, err := bot.GetHistoricCandles(exchName, p, asset.Spot, kline.OneHour, start, start.Add(time.Hour*2))
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v, received %v", common.ErrFunctionNotSupported, err)
+	}
+
+	bot.Settings.EnableCandleTradeFallback = true
+	item, err := bot.GetHistoricCandles(exchName, p, asset.Spot, kline.OneHour, start, start.Add(time.Hour*2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 2 {
+		t.Fatalf("expected %v, received %v", 2, len(item.Candles))
+	}
+	first := item.Candles[0]
+	if first.Open != 10 || first.High != 15 || first.Low != 10 || first.Close != 15 || first.Volume != 3 {
+		t.Errorf("unexpected first candle %+v", first)
+	}
+	second := item.Candles[1]
+	if second.Open != 12 || second.Close != 11 || second.Volume != 4 {
+		t.Errorf("unexpected second candle %+v", second)
+	}
+	if item.Exchange != exchName || item.Interval != kline.OneHour {
+		t.Errorf("unexpected candle item details %+v", item)
+	}
+}
+
+// fakeCancelAllExchange records the pair and asset of each cancel all request
+type fakeCancelAllExchange struct {
+	FakePassingExchange
+	cancelled []string
+}
+
+func (f *fakeCancelAllExchange) GetName() string { return f.Name }
+
+func (f *fakeCancelAllExchange) GetAssetTypes() asset.Items {
+	return asset.Items{asset.Spot, asset.Futures}
+}
+
+func (f *fakeCancelAllExchange) GetEnabledPairs(a asset.Item) (currency.Pairs, error) {
+	switch a {
+	case asset.Spot:
+		return currency.Pairs{
+			currency.NewPair(currency.BTC, currency.USD),
+			currency.NewPair(currency.ETH, currency.USD),
+		}, nil
+	case asset.Futures:
+		return currency.Pairs{currency.NewPair(currency.BTC, currency.USDT)}, nil
+	}
+	return nil, errors.New("asset not enabled")
+}
+
+func (f *fakeCancelAllExchange) CancelAllOrders(c *order.Cancel) (order.CancelAllResponse, error) {
+	id := c.AssetType.String() + " " + c.Pair.String()
+	f.cancelled = append(f.cancelled, id)
+	if c.Pair.Base == currency.ETH {
+		return order.CancelAllResponse{}, errors.New("cancel rejected")
+	}
+	return order.CancelAllResponse{
+		Status: map[string]string{id: order.Cancelled.String()},
+	}, nil
+}
+
+func TestCancelAllOrdersEverywhere(t *testing.T) {
+	t.Parallel()
+	const exchName = "cancelall"
+	bot := new(Engine)
+	_, err := bot.CancelAllOrdersEverywhere(exchName)
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("expected %v, received %v", ErrExchangeNotFound, err)
+	}
+
+	fake := &fakeCancelAllExchange{
+		FakePassingExchange: FakePassingExchange{
+			Base: exchange.Base{Name: exchName},
+		},
+	}
+	bot.exchangeManager.add(fake)
+
+	resp, err := bot.CancelAllOrdersEverywhere(exchName)
+	if err == nil {
+		t.Error("expected the failed pair to be reported")
+	}
+	expected := []string{"spot BTCUSD", "spot ETHUSD", "futures BTCUSDT"}
+	if len(fake.cancelled) != len(expected) {
+		t.Fatalf("expected %v cancel requests, received %v", len(expected), len(fake.cancelled))
+	}
+	for x := range expected {
+		if fake.cancelled[x] != expected[x] {
+			t.Errorf("expected %v, received %v", expected[x], fake.cancelled[x])
+		}
+	}
+	if len(resp.Status) != 2 ||
+		resp.Status["spot BTCUSD"] != order.Cancelled.String() ||
+		resp.Status["futures BTCUSDT"] != order.Cancelled.String() {
+		t.Errorf("unexpected aggregated statuses %v", resp.Status)
+	}
+}
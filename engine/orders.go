@@ -9,6 +9,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -18,6 +19,8 @@ var (
 	OrderManagerDelay      = time.Second * 10
 	ErrOrdersAlreadyExists = errors.New("order already exists")
 	ErrOrderNotFound       = errors.New("order does not exist")
+
+	errExchangeNotTrading = errors.New("exchange is not accepting orders")
 )
 
 // get returns all orders for all exchanges
@@ -311,6 +314,9 @@ func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, err
 	if exch == nil {
 		return nil, ErrExchangeNotFound
 	}
+	if err := checkTradingStatus(exch); err != nil {
+		return nil, err
+	}
 	result, err := exch.SubmitOrder(newOrder)
 	if err != nil {
 		return nil, err
@@ -448,3 +454,28 @@ func (o *orderManager) processOrders() {
 		}
 	}
 }
+
+// checkTradingStatus pauses order flow to exchanges which report they are in
+// maintenance or halted. A failed status lookup does not block the order.
+// This runs on every submission, which is acceptable only because exchanges
+// cache their status for DefaultTradingStatusTTL (30s), an uncached status
+// fetch would add a request to every order
+func checkTradingStatus(exch exchange.IBotExchange) error {
+	f, ok := exch.(exchange.TradingStatusFetcher)
+	if !ok {
+		return nil
+	}
+	status, err := f.GetTradingStatus()
+	if err != nil {
+		log.Warnf(log.OrderMgr,
+			"Order manager: unable to fetch %s trading status: %s\n",
+			exch.GetName(),
+			err)
+		return nil
+	}
+	switch status {
+	case exchange.TradingStatusMaintenance, exchange.TradingStatusHalted:
+		return fmt.Errorf("%s %w: %s", exch.GetName(), errExchangeNotTrading, status)
+	}
+	return nil
+}
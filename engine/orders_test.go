@@ -1,10 +1,12 @@
 package engine
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
@@ -392,3 +394,40 @@ func TestProcessOrders(t *testing.T) {
 	OrdersSetup(t)
 	Bot.OrderManager.processOrders()
 }
+
+// fakeStatusExchange reports a fixed trading status
+type fakeStatusExchange struct {
+	FakePassingExchange
+	status exchange.TradingStatus
+	err    error
+}
+
+func (f *fakeStatusExchange) GetName() string { return f.Name }
+
+func (f *fakeStatusExchange) GetTradingStatus() (exchange.TradingStatus, error) {
+	return f.status, f.err
+}
+
+func TestCheckTradingStatus(t *testing.T) {
+	t.Parallel()
+	exch := &fakeStatusExchange{status: exchange.TradingStatusMaintenance}
+	exch.Name = fakePassExchange
+	err := checkTradingStatus(exch)
+	if !errors.Is(err, errExchangeNotTrading) {
+		t.Errorf("expected %v, received %v", errExchangeNotTrading, err)
+	}
+
+	exch.status = exchange.TradingStatusOpen
+	if err = checkTradingStatus(exch); err != nil {
+		t.Errorf("expected %v, received %v", nil, err)
+	}
+
+	exch.err = errors.New("status endpoint unavailable")
+	if err = checkTradingStatus(exch); err != nil {
+		t.Errorf("expected %v, received %v", nil, err)
+	}
+
+	if err = checkTradingStatus(&FakePassingExchange{}); err != nil {
+		t.Errorf("expected %v, received %v", nil, err)
+	}
+}
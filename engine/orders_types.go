@@ -2,11 +2,16 @@ package engine
 
 import (
 	"sync"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
+// DefaultOrderPollInterval is the default delay between order status checks
+// when waiting for an order to reach a terminal state
+const DefaultOrderPollInterval = time.Second
+
 type orderManagerConfig struct {
 	EnforceLimitConfig     bool
 	AllowMarketOrders      bool
@@ -5,6 +5,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/database/repository/accountsnapshot"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 )
@@ -89,5 +91,46 @@ func (p *portfolioManager) processPortfolio() {
 			key,
 			value)
 	}
-	SeedExchangeAccountInfo(Bot.GetAllEnabledExchangeAccountInfo().Data)
+	accounts := Bot.GetAllEnabledExchangeAccountInfo().Data
+	SeedExchangeAccountInfo(accounts)
+	if Bot.Settings.EnableAccountSnapshots {
+		storeAccountSnapshots(accounts)
+	}
+}
+
+// storeAccountSnapshots persists the current exchange balances as a single
+// batch so balance history can be charted over time
+func storeAccountSnapshots(accounts []account.Holdings) {
+	snapshots := accountSnapshots(accounts, time.Now())
+	if len(snapshots) == 0 {
+		return
+	}
+	inserted, err := accountsnapshot.Insert(snapshots)
+	if err != nil {
+		log.Errorf(log.PortfolioMgr, "Portfolio manager unable to store account snapshots: %s\n", err)
+		return
+	}
+	log.Debugf(log.PortfolioMgr, "Portfolio manager stored %d account snapshot(s)\n", inserted)
+}
+
+// accountSnapshots flattens exchange holdings into account snapshots taken at
+// the supplied time
+func accountSnapshots(accounts []account.Holdings, ts time.Time) []accountsnapshot.Snapshot {
+	var snapshots []accountsnapshot.Snapshot
+	for x := range accounts {
+		for y := range accounts[x].Accounts {
+			for z := range accounts[x].Accounts[y].Currencies {
+				balance := accounts[x].Accounts[y].Currencies[z]
+				snapshots = append(snapshots, accountsnapshot.Snapshot{
+					Exchange:   accounts[x].Exchange,
+					SubAccount: accounts[x].Accounts[y].ID,
+					Currency:   balance.CurrencyName.String(),
+					Total:      balance.TotalValue,
+					Hold:       balance.Hold,
+					Timestamp:  ts,
+				})
+			}
+		}
+	}
+	return snapshots
 }
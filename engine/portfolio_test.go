@@ -0,0 +1,42 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+)
+
+func TestAccountSnapshots(t *testing.T) {
+	t.Parallel()
+	ts := time.Now()
+	snapshots := accountSnapshots([]account.Holdings{
+		{
+			Exchange: "Bitstamp",
+			Accounts: []account.SubAccount{
+				{
+					ID: "main",
+					Currencies: []account.Balance{
+						{CurrencyName: currency.BTC, TotalValue: 2, Hold: 1},
+						{CurrencyName: currency.USD, TotalValue: 100},
+					},
+				},
+				{
+					ID: "trading",
+				},
+			},
+		},
+	}, ts)
+	if len(snapshots) != 2 {
+		t.Fatalf("expected %v, received %v", 2, len(snapshots))
+	}
+	if snapshots[0].Exchange != "Bitstamp" ||
+		snapshots[0].SubAccount != "main" ||
+		snapshots[0].Currency != currency.BTC.String() ||
+		snapshots[0].Total != 2 ||
+		snapshots[0].Hold != 1 ||
+		!snapshots[0].Timestamp.Equal(ts) {
+		t.Errorf("unexpected snapshot %+v", snapshots[0])
+	}
+}
@@ -58,7 +58,8 @@ func printConvertCurrencyFormat(origCurrency currency.Code, origPrice float64) s
 
 func printTickerSummary(result *ticker.Price, protocol string, err error) {
 	if err != nil {
-		if err == common.ErrNotYetImplemented {
+		if errors.Is(err, common.ErrNotYetImplemented) ||
+			errors.Is(err, common.ErrFunctionNotSupported) {
 			log.Warnf(log.Ticker, "Failed to get %s ticker. Error: %s\n",
 				protocol,
 				err)
@@ -117,7 +118,8 @@ func printTickerSummary(result *ticker.Price, protocol string, err error) {
 
 func printOrderbookSummary(result *orderbook.Base, protocol string, err error) {
 	if err != nil {
-		if err == common.ErrNotYetImplemented {
+		if errors.Is(err, common.ErrNotYetImplemented) ||
+			errors.Is(err, common.ErrFunctionNotSupported) {
 			log.Warnf(log.Ticker, "Failed to get %s ticker. Error: %s\n",
 				protocol,
 				err)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes"
 	withdrawDataStore "github.com/thrasher-corp/gocryptotrader/database/repository/withdraw"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
@@ -19,8 +20,31 @@ const (
 	ErrRequestCannotbeNil = "request cannot be nil"
 	// StatusError const for for "error" string
 	StatusError = "error"
+
+	// cryptoWithdrawPermissions holds every permission which allows a
+	// cryptocurrency withdrawal to be submitted via the API
+	cryptoWithdrawPermissions = exchange.AutoWithdrawCrypto |
+		exchange.AutoWithdrawCryptoWithAPIPermission |
+		exchange.AutoWithdrawCryptoWithSetup |
+		exchange.WithdrawCryptoWith2FA |
+		exchange.WithdrawCryptoWithSMS |
+		exchange.WithdrawCryptoWithEmail |
+		exchange.WithdrawCryptoWithWebsiteApproval |
+		exchange.WithdrawCryptoWithAPIPermission
+	// fiatWithdrawPermissions holds every permission which allows a fiat
+	// withdrawal to be submitted via the API
+	fiatWithdrawPermissions = exchange.AutoWithdrawFiat |
+		exchange.AutoWithdrawFiatWithAPIPermission |
+		exchange.AutoWithdrawFiatWithSetup |
+		exchange.WithdrawFiatWith2FA |
+		exchange.WithdrawFiatWithSMS |
+		exchange.WithdrawFiatWithEmail |
+		exchange.WithdrawFiatWithWebsiteApproval |
+		exchange.WithdrawFiatWithAPIPermission
 )
 
+var errWithdrawalMethodUnsupported = errors.New("withdrawal method not supported via API")
+
 // SubmitWithdrawal preforms validation and submits a new withdraw request to exchange
 func SubmitWithdrawal(exchName string, req *withdraw.Request) (*withdraw.Response, error) {
 	if req == nil {
@@ -33,14 +57,19 @@ func SubmitWithdrawal(exchName string, req *withdraw.Request) (*withdraw.Respons
 		req.Exchange = exchName
 	}
 
-	err = withdraw.Validate(req)
+	exch := Bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+
+	err = checkWithdrawPermissions(exch, req.Type)
 	if err != nil {
 		return nil, err
 	}
 
-	exch := Bot.GetExchangeByName(exchName)
-	if exch == nil {
-		return nil, ErrExchangeNotFound
+	err = withdraw.Validate(req)
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &withdraw.Response{
@@ -53,6 +82,7 @@ func SubmitWithdrawal(exchName string, req *withdraw.Request) (*withdraw.Respons
 	if Bot.Settings.EnableDryRun {
 		log.Warnln(log.Global, "Dry run enabled, no withdrawal request will be submitted or have an event created")
 		resp.ID = withdraw.DryRunID
+		resp.DryRun = true
 		resp.Exchange.Status = "dryrun"
 		resp.Exchange.ID = withdraw.DryRunID.String()
 	} else {
@@ -83,6 +113,29 @@ func SubmitWithdrawal(exchName string, req *withdraw.Request) (*withdraw.Respons
 	return resp, nil
 }
 
+// checkWithdrawPermissions verifies the exchange permits API withdrawals of
+// the requested type
+func checkWithdrawPermissions(exch exchange.IBotExchange, t withdraw.RequestType) error {
+	var required uint32
+	var method string
+	switch t {
+	case withdraw.Crypto:
+		required, method = cryptoWithdrawPermissions, "crypto"
+	case withdraw.Fiat:
+		required, method = fiatWithdrawPermissions, "fiat"
+	default:
+		return withdraw.ErrInvalidRequest
+	}
+	if exch.GetWithdrawPermissions()&required == 0 {
+		return fmt.Errorf("%s %s %w: %s",
+			exch.GetName(),
+			method,
+			errWithdrawalMethodUnsupported,
+			exch.FormatWithdrawPermissions())
+	}
+	return nil
+}
+
 // WithdrawalEventByID returns a withdrawal request by ID
 func WithdrawalEventByID(id string) (*withdraw.Response, error) {
 	v := withdraw.Cache.Get(id)
@@ -103,6 +156,12 @@ func WithdrawalEventByExchange(exchange string, limit int) ([]*withdraw.Response
 	return withdrawDataStore.GetEventsByExchange(exchange, limit)
 }
 
+// WithdrawalEventsByExchangePaged returns a page of withdrawal requests by
+// exchange ordered by creation time
+func WithdrawalEventsByExchangePaged(exchange string, limit, offset int) ([]*withdraw.Response, error) {
+	return withdrawDataStore.GetEventsByExchangePaged(exchange, limit, offset)
+}
+
 // WithdrawEventByDate returns a withdrawal request by ID
 func WithdrawEventByDate(exchange string, start, end time.Time, limit int) ([]*withdraw.Response, error) {
 	return withdrawDataStore.GetEventsByDate(exchange, start, end, limit)
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,7 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
@@ -72,10 +75,29 @@ func TestSubmitWithdrawal(t *testing.T) {
 		},
 	}
 
-	_, err = SubmitWithdrawal(testExchange, req)
+	dryRun := Bot.Settings.EnableDryRun
+	defer func() { Bot.Settings.EnableDryRun = dryRun }()
+
+	Bot.Settings.EnableDryRun = true
+	resp, err := SubmitWithdrawal(testExchange, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.DryRun {
+		t.Error("expected dry run flag to be set")
+	}
+	if resp.ID != withdraw.DryRunID {
+		t.Errorf("expected %v, received %v", withdraw.DryRunID, resp.ID)
+	}
+
+	Bot.Settings.EnableDryRun = false
+	resp, err = SubmitWithdrawal(testExchange, req)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if resp.DryRun {
+		t.Error("expected dry run flag to be clear")
+	}
 
 	_, err = SubmitWithdrawal(testExchange, nil)
 	if err != nil {
@@ -115,6 +137,13 @@ func TestWithdrawalEventByExchange(t *testing.T) {
 	}
 }
 
+func TestWithdrawalEventsByExchangePaged(t *testing.T) {
+	_, err := WithdrawalEventsByExchangePaged(testExchange, 1, 1)
+	if err == nil {
+		t.Fatal(err)
+	}
+}
+
 func TestWithdrawEventByDate(t *testing.T) {
 	_, err := WithdrawEventByDate(testExchange, time.Now(), time.Now(), 1)
 	if err == nil {
@@ -176,3 +205,39 @@ func TestParseEvents(t *testing.T) {
 		t.Fatal("Expected second entry in slice to return a Request.Type of Crypto")
 	}
 }
+
+func TestSubmitWithdrawalPermissions(t *testing.T) {
+	SetupTestHelpers(t)
+	b := Bot.GetExchangeByName(testExchange).GetBase()
+	permissions := b.Features.Supports.WithdrawPermissions
+	defer func() { b.Features.Supports.WithdrawPermissions = permissions }()
+
+	req := &withdraw.Request{
+		Exchange:    testExchange,
+		Currency:    currency.BTC,
+		Description: testExchange,
+		Amount:      1.0,
+		Type:        withdraw.Crypto,
+		Crypto: &withdraw.CryptoRequest{
+			Address: core.BitcoinDonationAddress,
+		},
+	}
+
+	b.Features.Supports.WithdrawPermissions = exchange.NoAPIWithdrawalMethods
+	_, err := SubmitWithdrawal(testExchange, req)
+	if !errors.Is(err, errWithdrawalMethodUnsupported) {
+		t.Errorf("expected %v, received %v", errWithdrawalMethodUnsupported, err)
+	}
+
+	b.Features.Supports.WithdrawPermissions = exchange.AutoWithdrawFiat
+	_, err = SubmitWithdrawal(testExchange, req)
+	if !errors.Is(err, errWithdrawalMethodUnsupported) {
+		t.Errorf("expected %v, received %v", errWithdrawalMethodUnsupported, err)
+	}
+
+	b.Features.Supports.WithdrawPermissions = exchange.WithdrawCryptoWith2FA
+	err = checkWithdrawPermissions(Bot.GetExchangeByName(testExchange), withdraw.Crypto)
+	if err != nil {
+		t.Errorf("expected %v, received %v", nil, err)
+	}
+}
@@ -879,7 +879,7 @@ func (b *BTCMarkets) GetHistoricCandlesExtended(p currency.Pair, a asset.Item, s
 		Interval: interval,
 	}
 
-	dates := kline.CalcDateRanges(start, end, interval, b.Features.Enabled.Kline.ResultLimit)
+	dates := kline.CalcDateRanges(start, end, interval, b.Features.Enabled.Kline.ResultLimit, nil)
 	for x := range dates {
 		candles, err := b.GetMarketCandles(p.String(),
 			b.FormatExchangeKlineInterval(interval),
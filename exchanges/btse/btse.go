@@ -19,12 +19,29 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
 // BTSE is the overarching type across this package
 type BTSE struct {
 	exchange.Base
+	wsRoutes          *stream.Router
+	depositAddresses  depositAddressCache
+	withdrawPrecision withdrawalPrecisions
+	tradingStatus     exchange.TradingStatusCache
+
+	// wsSubscriptionLimit caps the channels sent in a single subscription
+	// message, larger requests are sent in batches paced by
+	// wsSubscriptionDelay
+	wsSubscriptionLimit int
+	wsSubscriptionDelay time.Duration
+
+	// wsPingInterval is how often the keepalive frame is sent, a pong not
+	// received within wsPongTimeout of a ping triggers a reconnect
+	wsPingInterval time.Duration
+	wsPongTimeout  time.Duration
+	wsPong         chan struct{}
 }
 
 const (
@@ -53,6 +70,10 @@ const (
 	btsePegOrder         = "order/peg"
 	btsePendingOrders    = "user/open_orders"
 	btseCancelAllAfter   = "order/cancelAllAfter"
+
+	walletDepositType = "Deposit"
+
+	defaultWithdrawalPrecision = 8
 )
 
 // GetMarketSummary stores market summary data
@@ -162,6 +183,14 @@ func (b *BTSE) GetWalletInformation() ([]CurrencyBalance, error) {
 	return a, b.SendAuthenticatedHTTPRequest(http.MethodGet, btseWallet, true, nil, nil, &a, queryFunc)
 }
 
+// GetFuturesWallets returns the balances of each futures wallet. The spot
+// wallet endpoint does not identify wallets, so futures wallets are queried
+// separately, see https://www.btse.com/apiexplorer/futures/#wallet
+func (b *BTSE) GetFuturesWallets() ([]FuturesWallet, error) {
+	var resp []FuturesWallet
+	return resp, b.SendAuthenticatedHTTPRequest(http.MethodGet, btseWallet, false, nil, nil, &resp, queryFunc)
+}
+
 // GetFeeInformation retrieve fee's (maker/taker) for requested symbol
 func (b *BTSE) GetFeeInformation(symbol string) ([]AccountFees, error) {
 	var resp []AccountFees
@@ -293,6 +322,50 @@ func (b *BTSE) CreateOrder(clOrderID string, deviation float64, postOnly bool, p
 	return r, b.SendAuthenticatedHTTPRequest(http.MethodPost, btseOrder, true, url.Values{}, req, &r, orderFunc)
 }
 
+// CreateFuturesOrder creates a futures order, reduceOnly ensures the order can
+// only reduce an existing position
+func (b *BTSE) CreateFuturesOrder(clOrderID string, postOnly, reduceOnly bool, price float64, side string, size float64, symbol, timeInForce string, triggerPrice float64, orderType string) ([]Order, error) {
+	req := futuresOrderRequest(clOrderID, postOnly, reduceOnly, price, side, size, symbol, timeInForce, triggerPrice, orderType)
+	var r []Order
+	return r, b.SendAuthenticatedHTTPRequest(http.MethodPost, btseOrder, false, url.Values{}, req, &r, orderFunc)
+}
+
+// futuresOrderRequest builds the request body for a futures order
+func futuresOrderRequest(clOrderID string, postOnly, reduceOnly bool, price float64, side string, size float64, symbol, timeInForce string, triggerPrice float64, orderType string) map[string]interface{} {
+	req := make(map[string]interface{})
+	if clOrderID != "" {
+		req["clOrderID"] = clOrderID
+	}
+	if postOnly {
+		req["postOnly"] = postOnly
+	}
+	if reduceOnly {
+		req["reduceOnly"] = reduceOnly
+	}
+	if price > 0.0 {
+		req["price"] = price
+	}
+	if side != "" {
+		req["side"] = side
+	}
+	if size > 0.0 {
+		req["size"] = size
+	}
+	if symbol != "" {
+		req["symbol"] = symbol
+	}
+	if timeInForce != "" {
+		req["time_in_force"] = timeInForce
+	}
+	if triggerPrice > 0.0 {
+		req["triggerPrice"] = triggerPrice
+	}
+	if orderType != "" {
+		req["type"] = orderType
+	}
+	return req
+}
+
 // GetOrders returns all pending orders
 func (b *BTSE) GetOrders(symbol, orderID, clOrderID string) ([]OpenOrder, error) {
 	req := url.Values{}
@@ -446,8 +519,8 @@ func (b *BTSE) SendAuthenticatedHTTPRequest(method, endpoint string, isSpot bool
 		host += btseSPOTPath + btseSPOTAPIPath + endpoint
 		endpoint = btseSPOTAPIPath + endpoint
 	} else {
-		host += btseFuturesPath + btseFuturesAPIPath
-		endpoint += btseFuturesAPIPath
+		host += btseFuturesPath + btseFuturesAPIPath + endpoint
+		endpoint = btseFuturesAPIPath + endpoint
 	}
 	var hmac []byte
 	var body io.Reader
@@ -561,26 +634,51 @@ func getInternationalBankWithdrawalFee(amount float64) float64 {
 	return fee
 }
 
+// GetTradeFeePercent returns the maker or taker trading fee rate for a pair as
+// a fraction of the traded value, e.g. 0.001 for 0.1%. Without credentials the
+// default fee schedule is returned
+func (b *BTSE) GetTradeFeePercent(pair currency.Pair, isMaker bool) (float64, error) {
+	if !b.AllowAuthenticatedRequest() {
+		return defaultTradingFee(isMaker), nil
+	}
+	return b.getTradingFeeRate(pair, isMaker)
+}
+
 // calculateTradingFee return fee based on users current fee tier or default values
 func (b *BTSE) calculateTradingFee(feeBuilder *exchange.FeeBuilder) float64 {
-	formattedPair, err := b.FormatExchangeCurrency(feeBuilder.Pair, asset.Spot)
+	fee, err := b.getTradingFeeRate(feeBuilder.Pair, feeBuilder.IsMaker)
 	if err != nil {
-		if feeBuilder.IsMaker {
-			return 0.001
-		}
-		return 0.002
+		return defaultTradingFee(feeBuilder.IsMaker)
+	}
+	return fee
+}
+
+// getTradingFeeRate returns the users current maker or taker fee tier for a
+// pair
+func (b *BTSE) getTradingFeeRate(pair currency.Pair, isMaker bool) (float64, error) {
+	formattedPair, err := b.FormatExchangeCurrency(pair, asset.Spot)
+	if err != nil {
+		return 0, err
 	}
 	feeTiers, err := b.GetFeeInformation(formattedPair.String())
 	if err != nil {
-		if feeBuilder.IsMaker {
-			return 0.001
-		}
-		return 0.002
+		return 0, err
+	}
+	if len(feeTiers) == 0 {
+		return 0, fmt.Errorf("%s %w", formattedPair, errNoFeeTiers)
 	}
-	if feeBuilder.IsMaker {
-		return feeTiers[0].MakerFee
+	if isMaker {
+		return feeTiers[0].MakerFee, nil
+	}
+	return feeTiers[0].TakerFee, nil
+}
+
+// defaultTradingFee returns the default maker or taker fee rate
+func defaultTradingFee(isMaker bool) float64 {
+	if isMaker {
+		return 0.001
 	}
-	return feeTiers[0].TakerFee
+	return 0.002
 }
 
 func parseOrderTime(timeStr string) (time.Time, error) {
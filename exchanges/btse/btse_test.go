@@ -1,13 +1,21 @@
 package btse
 
 import (
+	"encoding/json"
 	"errors"
 	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/core"
@@ -16,7 +24,11 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
 // Please supply your own keys here to do better tests
@@ -201,6 +213,101 @@ func TestGetHistoricCandlesExtended(t *testing.T) {
 	}
 }
 
+// newCandleServer returns a test server answering OHLCV requests with one
+// candle per minute between the requested start and end, counting requests
+func newCandleServer(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		start, err := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+		if err != nil {
+			t.Error(err)
+		}
+		end, err := strconv.ParseInt(r.URL.Query().Get("end"), 10, 64)
+		if err != nil {
+			t.Error(err)
+		}
+		resp := [][]float64{}
+		for ts := start; ts < end; ts += 60 {
+			resp = append(resp, []float64{float64(ts), 1, 2, 0.5, 1.5, 10})
+		}
+		if err = json.NewEncoder(w).Encode(resp); err != nil {
+			t.Error(err)
+		}
+	}))
+}
+
+// newCandleTestBTSE returns a BTSE instance with BTC-USD spot enabled pointing
+// at the supplied server
+func newCandleTestBTSE(t *testing.T, url string) (*BTSE, currency.Pair) {
+	t.Helper()
+	var bt BTSE
+	bt.SetDefaults()
+	bt.API.Endpoints.URL = url
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	p := currency.NewPairWithDelimiter("BTC", "USD", "-")
+	bt.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{p}, false)
+	bt.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{p}, true)
+	if err := bt.CurrencyPairs.SetAssetEnabled(asset.Spot, true); err != nil {
+		t.Fatal(err)
+	}
+	return &bt, p
+}
+
+func TestGetHistoricCandlesExtendedAssetResultLimit(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := newCandleServer(t, &requests)
+	defer server.Close()
+	bt, p := newCandleTestBTSE(t, server.URL)
+	bt.Features.Enabled.Kline.ResultLimits = map[asset.Item]uint32{
+		asset.Spot:    10,
+		asset.Futures: 500,
+	}
+
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-100 * time.Minute)
+	_, err := bt.GetHistoricCandles(p, asset.Spot, start, end, kline.OneMin)
+	if err == nil || err.Error() != kline.ErrRequestExceedsExchangeLimits {
+		t.Fatalf("expected %v, received %v", kline.ErrRequestExceedsExchangeLimits, err)
+	}
+	if atomic.LoadInt32(&requests) != 0 {
+		t.Errorf("expected no requests over the spot limit, received %v", requests)
+	}
+
+	// An unset asset limit falls back to the exchange wide limit
+	bt.Features.Enabled.Kline.ResultLimits[asset.Spot] = 0
+	item, err := bt.GetHistoricCandles(p, asset.Spot, start, end, kline.OneMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 100 {
+		t.Errorf("expected %v, received %v", 100, len(item.Candles))
+	}
+}
+
+func TestGetHistoricCandlesExtendedPagination(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := newCandleServer(t, &requests)
+	defer server.Close()
+	bt, p := newCandleTestBTSE(t, server.URL)
+	bt.Features.Enabled.Kline.ResultLimits = map[asset.Item]uint32{asset.Spot: 10}
+
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-100 * time.Minute)
+	item, err := bt.GetHistoricCandlesExtended(p, asset.Spot, start, end, kline.OneMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := atomic.LoadInt32(&requests); r < 2 {
+		t.Errorf("expected the request to be paged, received %v requests", r)
+	}
+	if len(item.Candles) != 100 {
+		t.Errorf("expected %v, received %v", 100, len(item.Candles))
+	}
+}
+
 func TestGetTrades(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetTrades(testPair,
@@ -241,6 +348,60 @@ func TestUpdateTicker(t *testing.T) {
 	}
 }
 
+func TestUpdateTickers(t *testing.T) {
+	t.Parallel()
+	// Both market summaries must be in flight together, proving the requests
+	// are fanned out rather than sent one after the other
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived.Done()
+		done := make(chan struct{})
+		go func() {
+			arrived.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Error("market summary requests were not sent concurrently")
+		}
+		resp := `[{"symbol":"BTC-USD","last":9000,"active":true}]`
+		if strings.HasPrefix(r.URL.Path, btseFuturesPath) {
+			resp = `[{"symbol":"BTC-PFC","last":9010,"active":true}]`
+		}
+		if _, err := w.Write([]byte(resp)); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.Name = "BTSEUpdateTickers"
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	err := bt.UpdateTickers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spot, err := ticker.GetTicker(bt.Name, currency.NewPairWithDelimiter("BTC", "USD", "-"), asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spot.Last != 9000 {
+		t.Errorf("expected %v, received %v", 9000, spot.Last)
+	}
+	futures, err := ticker.GetTicker(bt.Name, currency.NewPairWithDelimiter("BTC", "PFC", "-"), asset.Futures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if futures.Last != 9010 {
+		t.Errorf("expected %v, received %v", 9010, futures.Last)
+	}
+}
+
 func TestGetServerTime(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetServerTime()
@@ -387,6 +548,95 @@ func TestGetActiveOrders(t *testing.T) {
 	}
 }
 
+// newActiveOrdersServer returns a server answering open order requests with a
+// single order for the requested symbol and counting requests per symbol
+func newActiveOrdersServer(t *testing.T, requests map[string]int, m *sync.Mutex) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.URL.Path {
+		case btseSPOTPath + btseSPOTAPIPath + btsePendingOrders:
+			symbol := r.URL.Query().Get("symbol")
+			m.Lock()
+			requests[symbol]++
+			m.Unlock()
+			err = json.NewEncoder(w).Encode([]OpenOrder{{
+				OrderID:   symbol + "-order",
+				OrderType: 76,
+				Side:      order.Buy.String(),
+				Symbol:    symbol,
+				Timestamp: time.Now().Unix(),
+			}})
+		case btseSPOTPath + btseSPOTAPIPath + btseExchangeHistory:
+			_, err = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected path %v", r.URL.Path)
+		}
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+}
+
+func TestGetActiveOrdersPairs(t *testing.T) {
+	t.Parallel()
+	requests := make(map[string]int)
+	var m sync.Mutex
+	server := newActiveOrdersServer(t, requests, &m)
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.Name = "BTSEActiveOrders"
+	bt.SkipAuthCheck = true
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	btc := currency.NewPairWithDelimiter("BTC", "USD", "-")
+	eth := currency.NewPairWithDelimiter("ETH", "USD", "-")
+	xrp := currency.NewPairWithDelimiter("XRP", "USD", "-")
+	bt.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{btc, eth, xrp}, false)
+	bt.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{btc, eth}, true)
+	if err := bt.CurrencyPairs.SetAssetEnabled(asset.Spot, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// No pairs requested queries every enabled pair without touching the
+	// request
+	req := order.GetOrdersRequest{Type: order.AnyType}
+	orders, err := bt.GetActiveOrders(&req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Pairs) != 0 {
+		t.Errorf("expected request pairs to be left empty, received %v", req.Pairs)
+	}
+	if len(orders) != 2 {
+		t.Errorf("expected %v, received %v", 2, len(orders))
+	}
+	if requests["BTC-USD"] != 1 || requests["ETH-USD"] != 1 || requests["XRP-USD"] != 0 {
+		t.Errorf("unexpected requests per symbol %v", requests)
+	}
+
+	// Every requested pair is queried, not only the first
+	req = order.GetOrdersRequest{
+		Type:  order.AnyType,
+		Pairs: currency.Pairs{eth, xrp},
+	}
+	orders, err = bt.GetActiveOrders(&req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 2 {
+		t.Errorf("expected %v, received %v", 2, len(orders))
+	}
+	if !orders[0].Pair.Equal(eth) || !orders[1].Pair.Equal(xrp) {
+		t.Errorf("unexpected order pairs %v %v", orders[0].Pair, orders[1].Pair)
+	}
+	if requests["BTC-USD"] != 1 || requests["ETH-USD"] != 2 || requests["XRP-USD"] != 1 {
+		t.Errorf("unexpected requests per symbol %v", requests)
+	}
+}
+
 func TestGetExchangeHistory(t *testing.T) {
 	curr, _ := currency.NewPairFromString(testPair)
 	_, err := b.GetExchangeHistory(curr, asset.Spot, time.Now().AddDate(0, -6, 0), time.Now())
@@ -402,6 +652,25 @@ func TestGetExchangeHistory(t *testing.T) {
 	}
 }
 
+func TestUnimplementedVersusUnsupported(t *testing.T) {
+	t.Parallel()
+	var bt BTSE
+	bt.SetDefaults()
+	p := currency.NewPair(currency.BTC, currency.USD)
+	_, err := bt.GetExchangeHistory(p, asset.Futures, time.Now().Add(-time.Hour), time.Now())
+	if !errors.Is(err, common.ErrNotYetImplemented) {
+		t.Errorf("expected %v, received %v", common.ErrNotYetImplemented, err)
+	}
+	_, err = bt.GetExchangeHistory(p, asset.Margin, time.Now().Add(-time.Hour), time.Now())
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v, received %v", common.ErrFunctionNotSupported, err)
+	}
+	_, err = bt.WithdrawFiatFunds(&withdraw.Request{})
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v, received %v", common.ErrFunctionNotSupported, err)
+	}
+}
+
 func TestGetOrderHistory(t *testing.T) {
 	t.Parallel()
 	if !areTestAPIKeysSet() {
@@ -559,6 +828,71 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitPostOnlyOrder(t *testing.T) {
+	t.Parallel()
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("skipping test, either api keys are unset or canManipulateRealOrders is false")
+	}
+	response, err := b.SubmitOrder(&order.Submit{
+		Pair:      currency.NewPairWithDelimiter("BTC", "USD", "-"),
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     100,
+		Amount:    1,
+		PostOnly:  true,
+		AssetType: asset.Spot,
+	})
+	if err != nil || !response.IsOrderPlaced {
+		t.Errorf("Order failed to be placed: %v", err)
+	}
+}
+
+func TestSubmitPostOnlyMarketOrder(t *testing.T) {
+	t.Parallel()
+	_, err := b.SubmitOrder(&order.Submit{
+		Pair:      currency.NewPairWithDelimiter("BTC", "USD", "-"),
+		Side:      order.Buy,
+		Type:      order.Market,
+		Amount:    1,
+		PostOnly:  true,
+		AssetType: asset.Spot,
+	})
+	if !errors.Is(err, errPostOnlyMarketOrder) {
+		t.Errorf("expected %v, received %v", errPostOnlyMarketOrder, err)
+	}
+}
+
+func TestSubmitReduceOnlySpotOrder(t *testing.T) {
+	t.Parallel()
+	_, err := b.SubmitOrder(&order.Submit{
+		Pair:       currency.NewPairWithDelimiter("BTC", "USD", "-"),
+		Side:       order.Sell,
+		Type:       order.Limit,
+		Price:      100000,
+		Amount:     1,
+		ReduceOnly: true,
+		AssetType:  asset.Spot,
+	})
+	if !errors.Is(err, errReduceOnlyNotFutures) {
+		t.Errorf("expected %v, received %v", errReduceOnlyNotFutures, err)
+	}
+}
+
+func TestFuturesOrderRequest(t *testing.T) {
+	t.Parallel()
+	req := futuresOrderRequest("", false, true, 100000, order.Sell.String(), 1,
+		"BTCPFC", goodTillCancel, 0, order.Limit.String())
+	if reduceOnly, ok := req["reduceOnly"].(bool); !ok || !reduceOnly {
+		t.Errorf("expected reduceOnly to be set, received %v", req)
+	}
+
+	req = futuresOrderRequest("", false, false, 100000, order.Sell.String(), 1,
+		"BTCPFC", goodTillCancel, 0, order.Limit.String())
+	if _, ok := req["reduceOnly"]; ok {
+		t.Errorf("expected reduceOnly to be omitted, received %v", req)
+	}
+}
+
 func TestCancelAllAfter(t *testing.T) {
 	t.Parallel()
 	if !areTestAPIKeysSet() || !canManipulateRealOrders {
@@ -592,6 +926,32 @@ func TestCancelExchangeOrder(t *testing.T) {
 	}
 }
 
+func TestCancelExchangeOrderByClientOrderID(t *testing.T) {
+	t.Parallel()
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("skipping test, either api keys are unset or canManipulateRealOrders is false")
+	}
+	err := b.CancelOrder(&order.Cancel{
+		ClientOrderID: "gct-test-order",
+		Pair:          currency.NewPairWithDelimiter("BTC", "USD", "-"),
+		AssetType:     asset.Spot,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCancelExchangeOrderNoIDs(t *testing.T) {
+	t.Parallel()
+	err := b.CancelOrder(&order.Cancel{
+		Pair:      currency.NewPairWithDelimiter("BTC", "USD", "-"),
+		AssetType: asset.Spot,
+	})
+	if !errors.Is(err, errOrderIDNotSet) {
+		t.Errorf("expected %v, received %v", errOrderIDNotSet, err)
+	}
+}
+
 func TestCancelOrder(t *testing.T) {
 	t.Parallel()
 	if !areTestAPIKeysSet() || !canManipulateRealOrders {
@@ -823,3 +1183,889 @@ func TestWithinLimits(t *testing.T) {
 		t.Fatal("expected invalid limits")
 	}
 }
+
+func TestErrorLogFields(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPairWithDelimiter("BTC", "USD", "-")
+	got := errorLogFields("BTSE", "GetOrderInfo", p, asset.Spot, errors.New("bad"))
+	expected := `exchange=BTSE method=GetOrderInfo pair=BTC-USD asset=spot error="bad"`
+	if got != expected {
+		t.Errorf("expected %v, received %v", expected, got)
+	}
+
+	got = errorLogFields("BTSE", "Run", currency.Pair{}, "", errors.New("bad"))
+	expected = `exchange=BTSE method=Run error="bad"`
+	if got != expected {
+		t.Errorf("expected %v, received %v", expected, got)
+	}
+}
+
+func TestWalletSubAccounts(t *testing.T) {
+	t.Parallel()
+	spot := []CurrencyBalance{
+		{Currency: "XBT", Total: 1, Available: 0.5},
+		{Currency: "usdt", Total: 100, Available: 100},
+	}
+	subAccounts := walletSubAccounts(spot, nil)
+	if len(subAccounts) != 1 || subAccounts[0].ID != "" ||
+		len(subAccounts[0].Currencies) != 2 {
+		t.Fatalf("expected a single unnamed sub account, received %+v", subAccounts)
+	}
+	if !subAccounts[0].Currencies[0].CurrencyName.Match(currency.BTC) {
+		t.Errorf("expected %v, received %v",
+			currency.BTC, subAccounts[0].Currencies[0].CurrencyName)
+	}
+	if !subAccounts[0].Currencies[1].CurrencyName.Match(currency.USDT) {
+		t.Errorf("expected %v, received %v",
+			currency.USDT, subAccounts[0].Currencies[1].CurrencyName)
+	}
+
+	var futures []FuturesWallet
+	err := json.Unmarshal([]byte(`[
+		{"wallet":"CROSS@","totalValue":150,"availableBalance":120,"assets":[{"currency":"USD","balance":100,"assetPrice":1},{"currency":"BTC","balance":0.005,"assetPrice":10000}]},
+		{"wallet":"ISOLATED@BTCPFC-USD","totalValue":10,"availableBalance":10,"assets":[{"currency":"USD","balance":10,"assetPrice":1}]}
+	]`), &futures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subAccounts = walletSubAccounts(spot, futures)
+	if len(subAccounts) != 3 {
+		t.Fatalf("expected %v, received %v", 3, len(subAccounts))
+	}
+	if subAccounts[0].ID != spotWalletID || len(subAccounts[0].Currencies) != 2 {
+		t.Errorf("unexpected spot sub account %+v", subAccounts[0])
+	}
+	if subAccounts[1].ID != "CROSS@" || len(subAccounts[1].Currencies) != 2 ||
+		subAccounts[1].Currencies[1].TotalValue != 0.005 {
+		t.Errorf("unexpected cross sub account %+v", subAccounts[1])
+	}
+	if subAccounts[2].ID != "ISOLATED@BTCPFC-USD" || len(subAccounts[2].Currencies) != 1 {
+		t.Errorf("unexpected isolated sub account %+v", subAccounts[2])
+	}
+}
+
+func TestUpdateAccountInfoSubAccounts(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.URL.Path {
+		case btseSPOTPath + btseSPOTAPIPath + btseWallet:
+			_, err = w.Write([]byte(`[{"currency":"BTC","total":1,"available":0.5}]`))
+		case btseFuturesPath + btseFuturesAPIPath + btseWallet:
+			_, err = w.Write([]byte(`[{"wallet":"CROSS@","assets":[{"currency":"USD","balance":100}]}]`))
+		default:
+			t.Errorf("unexpected path %v", r.URL.Path)
+		}
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.Name = "BTSESubAccounts"
+	bt.SkipAuthCheck = true
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	if err := bt.CurrencyPairs.SetAssetEnabled(asset.Futures, true); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := bt.UpdateAccountInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Accounts) != 2 || h.Accounts[0].ID != spotWalletID || h.Accounts[1].ID != "CROSS@" {
+		t.Errorf("unexpected sub accounts %+v", h.Accounts)
+	}
+
+	if err = bt.CurrencyPairs.SetAssetEnabled(asset.Futures, false); err != nil {
+		t.Fatal(err)
+	}
+	h, err = bt.UpdateAccountInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Accounts) != 1 || h.Accounts[0].ID != "" {
+		t.Errorf("expected a single unnamed sub account, received %+v", h.Accounts)
+	}
+}
+
+func TestSubmitResponse(t *testing.T) {
+	t.Parallel()
+	_, err := submitResponse(nil, 1)
+	if !errors.Is(err, errNoOrderResponse) {
+		t.Errorf("expected %v, received %v", errNoOrderResponse, err)
+	}
+
+	var r []Order
+	err = json.Unmarshal([]byte(`[{"orderID":"1337","fillSize":0.4,"size":1,"status":5},{"orderID":"1337","fillSize":0.2,"size":1,"status":5}]`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := submitResponse(r, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.IsOrderPlaced || resp.OrderID != "1337" {
+		t.Errorf("unexpected submit response %+v", resp)
+	}
+	if resp.FullyMatched {
+		t.Error("expected partially filled market order to not be fully matched")
+	}
+	if math.Abs(resp.FilledAmount-0.6) > 1e-9 {
+		t.Errorf("expected %v, received %v", 0.6, resp.FilledAmount)
+	}
+
+	resp, err = submitResponse([]Order{{OrderID: "1", FillSize: 1}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.FullyMatched || resp.FilledAmount != 1 {
+		t.Errorf("unexpected submit response %+v", resp)
+	}
+
+	resp, err = submitResponse([]Order{{OrderID: "2", Status: 2}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.IsOrderPlaced || resp.Status != order.New || resp.RejectReason != "" {
+		t.Errorf("unexpected accepted submit response %+v", resp)
+	}
+
+	resp, err = submitResponse([]Order{{OrderID: "3", Status: 8, Message: "insufficient funds"}}, 1)
+	if !errors.Is(err, errOrderRejected) {
+		t.Errorf("expected %v, received %v", errOrderRejected, err)
+	}
+	if resp.IsOrderPlaced || resp.Status != order.InsufficientBalance ||
+		resp.RejectReason != "insufficient funds" {
+		t.Errorf("unexpected rejected submit response %+v", resp)
+	}
+
+	resp, err = submitResponse([]Order{{OrderID: "4", Status: 15}}, 1)
+	if !errors.Is(err, errOrderRejected) {
+		t.Errorf("expected %v, received %v", errOrderRejected, err)
+	}
+	if resp.RejectReason != order.Rejected.String() {
+		t.Errorf("expected %v, received %v", order.Rejected, resp.RejectReason)
+	}
+}
+
+func TestGetDepositAddressCache(t *testing.T) {
+	t.Parallel()
+	var gets, creates int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := `[]`
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&creates, 1)
+			resp = `[{"address":"created","created":1}]`
+		} else {
+			atomic.AddInt32(&gets, 1)
+		}
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.Name = "BTSE"
+	bt.SkipAuthCheck = true
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name,
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+
+	for i := 0; i < 2; i++ {
+		addr, err := bt.GetDepositAddress(currency.BTC, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if addr != "created" {
+			t.Errorf("expected %v, received %v", "created", addr)
+		}
+	}
+	if gets != 1 || creates != 1 {
+		t.Errorf("expected a single fetch and create, received %v fetches and %v creates", gets, creates)
+	}
+
+	bt.InvalidateDepositAddress(currency.BTC)
+	_, err := bt.GetDepositAddress(currency.BTC, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gets != 2 || creates != 2 {
+		t.Errorf("expected invalidated address to be fetched again, received %v fetches and %v creates", gets, creates)
+	}
+}
+
+func TestOpenInterest(t *testing.T) {
+	t.Parallel()
+	var m MarketSummary
+	err := json.Unmarshal([]byte(`[{"symbol":"BTCPFC","last":11400.5,"openInterest":2512345,"openInterestUSD":2512345,"futures":true},{"symbol":"ETHPFC","last":370.1,"openInterest":81234,"openInterestUSD":81234,"futures":true}]`), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi, err := openInterest(m, "ethpfc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi != 81234 {
+		t.Errorf("expected %v, received %v", 81234, oi)
+	}
+	_, err = openInterest(m, "LTCPFC")
+	if !errors.Is(err, errMarketNotFound) {
+		t.Errorf("expected %v, received %v", errMarketNotFound, err)
+	}
+}
+
+// recordingConn records the subscription messages sent to it
+type recordingConn struct {
+	stream.Connection
+	sent []wsSub
+}
+
+func (r *recordingConn) SendJSONMessage(m interface{}) error {
+	r.sent = append(r.sent, m.(wsSub))
+	return nil
+}
+
+func TestSubscribeBatches(t *testing.T) {
+	t.Parallel()
+	conn := &recordingConn{}
+	var bt BTSE
+	bt.Websocket = stream.New()
+	bt.Websocket.Conn = conn
+	bt.wsSubscriptionLimit = 2
+
+	var subs []stream.ChannelSubscription
+	for _, c := range []string{"a", "b", "c", "d", "e"} {
+		subs = append(subs, stream.ChannelSubscription{Channel: "tradeHistory:" + c})
+	}
+	err := bt.Subscribe(subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conn.sent) != 3 {
+		t.Fatalf("expected %v, received %v", 3, len(conn.sent))
+	}
+	for x, expected := range []int{2, 2, 1} {
+		if len(conn.sent[x].Arguments) != expected {
+			t.Errorf("expected %v, received %v", expected, len(conn.sent[x].Arguments))
+		}
+	}
+	subscribed := bt.Websocket.GetSubscriptions()
+	if len(subscribed) != len(subs) {
+		t.Errorf("expected %v, received %v", len(subs), len(subscribed))
+	}
+
+	conn.sent = nil
+	bt.wsSubscriptionLimit = 0
+	err = bt.Subscribe(subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conn.sent) != 1 || len(conn.sent[0].Arguments) != len(subs) {
+		t.Errorf("expected a single uncapped subscription message, received %+v", conn.sent)
+	}
+}
+
+func TestUnsubscribeAll(t *testing.T) {
+	t.Parallel()
+	conn := &recordingConn{}
+	var bt BTSE
+	bt.Websocket = stream.New()
+	bt.Websocket.Conn = conn
+
+	err := bt.UnsubscribeAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conn.sent) != 0 {
+		t.Errorf("expected no messages sent, received %+v", conn.sent)
+	}
+
+	err = bt.Subscribe([]stream.ChannelSubscription{
+		{Channel: "tradeHistory:BTC-USD"},
+		{Channel: "orderBookApi:BTC-USD_0"},
+		{Channel: "notificationApi"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bt.UnsubscribeAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conn.sent) != 2 || conn.sent[1].Operation != "unsubscribe" ||
+		len(conn.sent[1].Arguments) != 3 {
+		t.Errorf("unexpected messages sent %+v", conn.sent)
+	}
+	if subs := bt.Websocket.GetSubscriptions(); len(subs) != 0 {
+		t.Errorf("expected %v, received %v", 0, len(subs))
+	}
+}
+
+func TestTradeSide(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		side     string
+		gain     int64
+		expected order.Side
+	}{
+		{side: "BUY", expected: order.Buy},
+		{side: "sell", expected: order.Sell},
+		{side: " Sell ", expected: order.Sell},
+		{side: "bid", expected: order.Buy},
+		{side: "ASK", expected: order.Sell},
+		{side: "", gain: 1, expected: order.Buy},
+		{side: "", gain: -1, expected: order.Sell},
+		{side: "", expected: order.UnknownSide},
+		{side: "ANY", expected: order.UnknownSide},
+		{side: "lol", gain: -1, expected: order.Sell},
+		{side: "lol", expected: order.UnknownSide},
+	}
+	for x := range testCases {
+		if s := tradeSide(testCases[x].side, testCases[x].gain); s != testCases[x].expected {
+			t.Errorf("%q gain %d: expected %v, received %v",
+				testCases[x].side, testCases[x].gain, testCases[x].expected, s)
+		}
+	}
+}
+
+func TestGetDepositHistory(t *testing.T) {
+	t.Parallel()
+	var symbol string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbol = r.URL.Query().Get("symbol")
+		_, err := w.Write([]byte(`[{"amount":0.5,"currency":"XBT","description":"deposit 3fa1","fees":0,"orderId":"1337","status":"Completed","timestamp":1602928800000,"type":"Deposit","username":"gct","wallet":"SPOT@"},{"amount":0.1,"currency":"BTC","description":"withdraw","fees":0.0005,"orderId":"1338","status":"Pending","timestamp":1602932400000,"type":"Withdraw","username":"gct","wallet":"SPOT@"}]`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.Name = "BTSE"
+	bt.SkipAuthCheck = true
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name,
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+
+	deposits, err := bt.GetDepositHistory(currency.NewCode("btc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if symbol != "BTC" {
+		t.Errorf("expected %v, received %v", "BTC", symbol)
+	}
+	if len(deposits) != 1 {
+		t.Fatalf("expected %v, received %v", 1, len(deposits))
+	}
+	d := deposits[0]
+	if d.ExchangeName != "BTSE" || d.Currency != "BTC" || d.Amount != 0.5 ||
+		d.Status != "Completed" || d.TransferID != "1337" ||
+		d.TransferType != "Deposit" || d.Description != "deposit 3fa1" {
+		t.Errorf("unexpected deposit %+v", d)
+	}
+	if !d.Timestamp.Equal(time.Unix(1602928800, 0)) {
+		t.Errorf("expected %v, received %v", time.Unix(1602928800, 0), d.Timestamp)
+	}
+
+	history, err := bt.GetFundingHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[1].Fee != 0.0005 {
+		t.Errorf("unexpected funding history %+v", history)
+	}
+}
+
+func TestDataSourceSupport(t *testing.T) {
+	t.Parallel()
+	var bt BTSE
+	bt.SetDefaults()
+	if !bt.SupportsWebsocketOrderbook() {
+		t.Error("expected websocket orderbook support")
+	}
+	if bt.SupportsWebsocketTicker() || bt.SupportsWebsocketKline() {
+		t.Error("expected no websocket ticker or kline support")
+	}
+	if !bt.SupportsRESTOrderbook() || !bt.SupportsRESTTicker() || !bt.SupportsRESTKline() {
+		t.Error("expected REST orderbook, ticker and kline support")
+	}
+	bt.Features.Supports.Websocket = false
+	if bt.SupportsWebsocketOrderbook() {
+		t.Error("expected no websocket orderbook support without websocket support")
+	}
+}
+
+func TestSubmitOrderLimitsUnavailable(t *testing.T) {
+	t.Parallel()
+	var bt BTSE
+	bt.SetDefaults()
+	_, err := bt.SubmitOrder(&order.Submit{
+		Pair:      currency.NewPairWithDelimiter("GCT", "USD", "-"),
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     1,
+		Amount:    1,
+		AssetType: asset.Spot,
+	})
+	if !errors.Is(err, ErrOrderLimitsUnavailable) {
+		t.Errorf("expected %v, received %v", ErrOrderLimitsUnavailable, err)
+	}
+}
+
+func TestSubmitOrderFuturesContracts(t *testing.T) {
+	// Not parallel, seedOrderSizeLimitMap clears the shared limit map
+	var size float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		size, _ = body["size"].(float64)
+		_, _ = w.Write([]byte(`[{"orderID":"1337","status":4,"fillSize":250}]`))
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.SkipAuthCheck = true
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+
+	p := currency.NewPair(currency.NewCode("GCT"), currency.NewCode("PFC"))
+	fPair, err := bt.FormatExchangeCurrency(p, asset.Futures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderSizeLimitMap.Store(fPair.String(), OrderSizeLimit{
+		MinOrderSize:     1,
+		MaxOrderSize:     1000000,
+		MinSizeIncrement: 1,
+		ContractSize:     0.001,
+	})
+	defer orderSizeLimitMap.Delete(fPair.String())
+
+	s := &order.Submit{
+		Pair:      p,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     100,
+		Amount:    0.25,
+		AssetType: asset.Futures,
+	}
+	resp, err := bt.SubmitOrder(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 250 {
+		t.Errorf("expected %v, received %v", 250, size)
+	}
+	if math.Abs(resp.FilledAmount-0.25) > 1e-12 {
+		t.Errorf("expected %v, received %v", 0.25, resp.FilledAmount)
+	}
+
+	s.Amount = 0.0005
+	_, err = bt.SubmitOrder(s)
+	if !errors.Is(err, order.ErrFractionalContracts) {
+		t.Errorf("expected %v, received %v", order.ErrFractionalContracts, err)
+	}
+}
+
+func TestFormatWithdrawalAmount(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		amount    float64
+		precision int
+		expected  string
+		err       error
+	}{
+		{amount: 0.123456789123, precision: 8, expected: "0.12345678"},
+		{amount: 1.98765, precision: 2, expected: "1.98"},
+		{amount: 1.5, precision: 8, expected: "1.5"},
+		{amount: 12.9, precision: 0, expected: "12"},
+		{amount: 0.000000001, precision: 8, err: errWithdrawAmountZero},
+	} {
+		s, err := formatWithdrawalAmount(tc.amount, tc.precision)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("expected %v, received %v", tc.err, err)
+		}
+		if s != tc.expected {
+			t.Errorf("expected %v, received %v", tc.expected, s)
+		}
+	}
+}
+
+func TestWithdrawCryptocurrencyFundsPrecision(t *testing.T) {
+	t.Parallel()
+	var amount string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		amount, _ = body["amount"].(string)
+		_, _ = w.Write([]byte(`{"withdraw_id":"1337"}`))
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.SkipAuthCheck = true
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+
+	err := bt.SetWithdrawalPrecision(currency.USDT, -1)
+	if !errors.Is(err, errInvalidPrecision) {
+		t.Errorf("expected %v, received %v", errInvalidPrecision, err)
+	}
+	err = bt.SetWithdrawalPrecision(currency.USDT, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = bt.WithdrawCryptocurrencyFunds(&withdraw.Request{
+		Currency: currency.USDT,
+		Amount:   10.123456,
+		Crypto:   &withdraw.CryptoRequest{Address: "test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount != "10.12" {
+		t.Errorf("expected %v, received %v", "10.12", amount)
+	}
+
+	_, err = bt.WithdrawCryptocurrencyFunds(&withdraw.Request{
+		Currency: currency.USDT,
+		Amount:   0.001,
+		Crypto:   &withdraw.CryptoRequest{Address: "test"},
+	})
+	if !errors.Is(err, errWithdrawAmountZero) {
+		t.Errorf("expected %v, received %v", errWithdrawAmountZero, err)
+	}
+}
+
+func TestGetTradeFeePercent(t *testing.T) {
+	t.Parallel()
+	resp := `[{"makerFee":0.0005,"symbol":"BTC-USD","takerFee":0.0015}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	p := currency.NewPairWithDelimiter("BTC", "USD", "-")
+
+	// without credentials the default fee schedule is used
+	maker, err := bt.GetTradeFeePercent(p, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maker != 0.001 {
+		t.Errorf("expected %v, received %v", 0.001, maker)
+	}
+	taker, err := bt.GetTradeFeePercent(p, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taker != 0.002 {
+		t.Errorf("expected %v, received %v", 0.002, taker)
+	}
+
+	bt.SkipAuthCheck = true
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	maker, err = bt.GetTradeFeePercent(p, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maker != 0.0005 {
+		t.Errorf("expected %v, received %v", 0.0005, maker)
+	}
+	taker, err = bt.GetTradeFeePercent(p, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taker != 0.0015 {
+		t.Errorf("expected %v, received %v", 0.0015, taker)
+	}
+
+	// with credentials a failed lookup is surfaced rather than defaulted
+	resp = `[]`
+	_, err = bt.GetTradeFeePercent(p, true)
+	if !errors.Is(err, errNoFeeTiers) {
+		t.Errorf("expected %v, received %v", errNoFeeTiers, err)
+	}
+}
+
+func TestGetLatestCandle(t *testing.T) {
+	t.Parallel()
+	current := time.Now().Truncate(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp [][]float64
+		for x := 3; x >= 0; x-- {
+			ts := current.Add(-time.Hour * time.Duration(x)).Unix()
+			resp = append(resp, []float64{float64(ts), 1, 2, 0.5, float64(x), 10})
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	p := currency.NewPairWithDelimiter("BTC", "USD", "-")
+	bt.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{p}, false)
+	bt.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{p}, true)
+	if err := bt.CurrencyPairs.SetAssetEnabled(asset.Spot, true); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := bt.GetLatestCandle(p, asset.Spot, kline.OneHour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := current.Add(-time.Hour); !c.Time.Equal(expected) {
+		t.Errorf("expected %v, received %v", expected, c.Time)
+	}
+	if c.Close != 1 {
+		t.Errorf("expected %v, received %v", 1, c.Close)
+	}
+}
+
+func TestSubmitOCOOrder(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if body["type"] != ocoOrder || body["price"] != 12000.0 || body["triggerPrice"] != 9000.0 {
+			t.Errorf("unexpected oco request %v", body)
+		}
+		_, err := w.Write([]byte(`[{"orderID":"tp","price":12000,"side":"SELL","size":1,"status":2,"symbol":"BTC-USD","trigger":false},{"orderID":"sl","side":"SELL","size":1,"status":2,"symbol":"BTC-USD","trigger":true,"triggerPrice":9000}]`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.SkipAuthCheck = true
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+
+	p := currency.NewPairWithDelimiter("BTC", "USD", "-")
+	takeProfit := &order.Submit{
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      order.Sell,
+		Type:      order.Limit,
+		Amount:    1,
+		Price:     12000,
+	}
+	stopLoss := &order.Submit{
+		Pair:         p,
+		AssetType:    asset.Spot,
+		Side:         order.Sell,
+		Type:         order.Stop,
+		Amount:       1,
+		TriggerPrice: 9000,
+	}
+	resp, err := bt.SubmitOCOOrder(takeProfit, stopLoss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.TakeProfitOrderID != "tp" || resp.StopLossOrderID != "sl" {
+		t.Errorf("unexpected oco response %+v", resp)
+	}
+
+	// a stop loss triggering above the take profit for a sell leaves both
+	// legs on the same side of the market
+	stopLoss.TriggerPrice = 13000
+	_, err = bt.SubmitOCOOrder(takeProfit, stopLoss)
+	if !errors.Is(err, errOCOLegsSameSide) {
+		t.Errorf("expected %v, received %v", errOCOLegsSameSide, err)
+	}
+
+	stopLoss.TriggerPrice = 9000
+	stopLoss.Side = order.Buy
+	_, err = bt.SubmitOCOOrder(takeProfit, stopLoss)
+	if !errors.Is(err, errOCOLegMismatch) {
+		t.Errorf("expected %v, received %v", errOCOLegMismatch, err)
+	}
+}
+
+func TestGetTradingStatus(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`[{"symbol":"BTC-USD","active":false},{"symbol":"ETH-USD","active":false}]`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	status, err := bt.GetTradingStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != exchange.TradingStatusMaintenance {
+		t.Errorf("expected %v, received %v", exchange.TradingStatusMaintenance, status)
+	}
+
+	m := MarketSummary{{Symbol: "BTC-USD"}, {Symbol: "ETH-USD", Active: true}}
+	if s := tradingStatusFromMarkets(m); s != exchange.TradingStatusOpen {
+		t.Errorf("expected %v, received %v", exchange.TradingStatusOpen, s)
+	}
+	if s := tradingStatusFromMarkets(nil); s != exchange.TradingStatusUnknown {
+		t.Errorf("expected %v, received %v", exchange.TradingStatusUnknown, s)
+	}
+}
+
+type heartbeatConn struct {
+	stream.Connection
+	m     sync.Mutex
+	pings []time.Time
+	pong  func(string) error
+	err   error
+}
+
+func (h *heartbeatConn) SendRawMessage(messageType int, _ []byte) error {
+	if messageType != websocket.PingMessage {
+		return nil
+	}
+	h.m.Lock()
+	h.pings = append(h.pings, time.Now())
+	h.m.Unlock()
+	if h.err != nil {
+		return h.err
+	}
+	if h.pong != nil {
+		return h.pong("")
+	}
+	return nil
+}
+
+func (h *heartbeatConn) sent() []time.Time {
+	h.m.Lock()
+	defer h.m.Unlock()
+	return append([]time.Time(nil), h.pings...)
+}
+
+func TestWsHeartbeat(t *testing.T) {
+	t.Parallel()
+	newBTSE := func(conn *heartbeatConn) *BTSE {
+		bt := new(BTSE)
+		bt.SetDefaults()
+		bt.Websocket.ShutdownC = make(chan struct{})
+		bt.Websocket.Wg = new(sync.WaitGroup)
+		bt.Websocket.Conn = conn
+		bt.wsPong = make(chan struct{}, 1)
+		return bt
+	}
+	waitForExit := func(bt *BTSE) {
+		done := make(chan struct{})
+		go func() {
+			bt.Websocket.Wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("heartbeat did not exit")
+		}
+	}
+
+	const interval = time.Millisecond * 20
+	conn := new(heartbeatConn)
+	bt := newBTSE(conn)
+	conn.pong = bt.wsHandlePong
+	bt.Websocket.Wg.Add(1)
+	go bt.wsHeartbeat(interval, time.Second)
+	time.Sleep(interval*5 + interval/2)
+	close(bt.Websocket.ShutdownC)
+	waitForExit(bt)
+
+	pings := conn.sent()
+	if len(pings) < 4 || len(pings) > 6 {
+		t.Fatalf("expected around %v pings, received %v", 5, len(pings))
+	}
+	for x := 1; x < len(pings); x++ {
+		if gap := pings[x].Sub(pings[x-1]); gap < interval/2 {
+			t.Errorf("expected pings %v apart, received %v", interval, gap)
+		}
+	}
+
+	// Without pongs the heartbeat gives up on the connection once the window
+	// elapses
+	conn = new(heartbeatConn)
+	bt = newBTSE(conn)
+	bt.Websocket.Wg.Add(1)
+	go bt.wsHeartbeat(time.Millisecond*10, time.Millisecond*30)
+	waitForExit(bt)
+	sent := len(conn.sent())
+	time.Sleep(time.Millisecond * 50)
+	if len(conn.sent()) != sent {
+		t.Error("expected pings to stop after a missed pong")
+	}
+
+	// A ping which cannot be sent is treated as a missed pong without waiting
+	// for the window to elapse
+	conn = &heartbeatConn{err: errors.New("broken pipe")}
+	bt = newBTSE(conn)
+	bt.Websocket.Wg.Add(1)
+	go bt.wsHeartbeat(time.Millisecond*10, time.Minute)
+	waitForExit(bt)
+	time.Sleep(time.Millisecond * 50)
+	if sent := len(conn.sent()); sent != 1 {
+		t.Errorf("expected pings to stop after a failed send, received %v", sent)
+	}
+}
+
+func TestGetTickerBatch(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := `[{"symbol":"BTC-USD","last":9000,"active":true}]`
+		if strings.HasPrefix(r.URL.Path, btseFuturesPath) {
+			resp = `[{"symbol":"BTCPFC","last":9010,"active":true},{"symbol":"ETHPFC","last":200,"active":true}]`
+		}
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var bt BTSE
+	bt.SetDefaults()
+	bt.API.Endpoints.URL = server.URL
+	bt.Requester = request.New(bt.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	batch, err := bt.GetTickerBatch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch[asset.Spot]) != 1 || batch[asset.Spot][0].Symbol != "BTC-USD" {
+		t.Errorf("unexpected spot summaries %+v", batch[asset.Spot])
+	}
+	if len(batch[asset.Futures]) != 2 || batch[asset.Futures][0].Symbol != "BTCPFC" {
+		t.Errorf("unexpected futures summaries %+v", batch[asset.Futures])
+	}
+}
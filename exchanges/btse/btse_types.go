@@ -1,6 +1,7 @@
 package btse
 
 import (
+	"errors"
 	"sync"
 	"time"
 )
@@ -8,6 +9,8 @@ import (
 const (
 	// Default order type is good till cancel (or filled)
 	goodTillCancel = "GTC"
+	// ocoOrder links a limit and a stop leg, filling one cancels the other
+	ocoOrder = "OCO"
 
 	orderInserted  = 2
 	orderCancelled = 6
@@ -168,6 +171,23 @@ type CurrencyBalance struct {
 	Available float64 `json:"available"`
 }
 
+// FuturesWallet stores the balances held in a single futures wallet such as
+// the cross margin wallet CROSS@, see
+// https://www.btse.com/apiexplorer/futures/#wallet
+type FuturesWallet struct {
+	Wallet           string               `json:"wallet"`
+	TotalValue       float64              `json:"totalValue"`
+	AvailableBalance float64              `json:"availableBalance"`
+	Assets           []FuturesWalletAsset `json:"assets"`
+}
+
+// FuturesWalletAsset stores a currency balance within a futures wallet
+type FuturesWalletAsset struct {
+	Currency   string  `json:"currency"`
+	Balance    float64 `json:"balance"`
+	AssetPrice float64 `json:"assetPrice"`
+}
+
 // AccountFees stores fee for each currency pair
 type AccountFees struct {
 	MakerFee float64 `json:"makerFee"`
@@ -342,12 +362,95 @@ type ErrorResponse struct {
 	Status    int    `json:"status"`
 }
 
+// OCOResponse holds the linked order IDs of a one-cancels-other order
+type OCOResponse struct {
+	TakeProfitOrderID string
+	StopLossOrderID   string
+}
+
 // OrderSizeLimit holds accepted minimum, maximum, and size increment when submitting new orders
 type OrderSizeLimit struct {
 	MinOrderSize     float64
 	MaxOrderSize     float64
 	MinSizeIncrement float64
+	// ContractSize is the base currency amount of one futures contract,
+	// futures order sizes and limits are expressed in contracts
+	ContractSize float64
 }
 
 // orderSizeLimitMap map of OrderSizeLimit per currency
 var orderSizeLimitMap sync.Map
+
+var (
+	errOrderIDNotSet        = errors.New("order ID or client order ID must be set")
+	errPostOnlyMarketOrder  = errors.New("post only cannot be used with market orders")
+	errReduceOnlyNotFutures = errors.New("reduce only is only supported for futures orders")
+	errNoOrderResponse      = errors.New("no order returned in create order response")
+	errOrderRejected        = errors.New("order rejected")
+	errMarketNotFound       = errors.New("market not found in market summary")
+	errWithdrawAmountZero   = errors.New("withdrawal amount is zero after rounding to precision")
+	errInvalidPrecision     = errors.New("precision cannot be negative")
+	errOCOLegMismatch       = errors.New("oco legs must share pair, side and amount")
+	errOCOLegsSameSide      = errors.New("oco legs must be priced on opposite sides of the market")
+	errOCOStopTriggerNotSet = errors.New("oco stop loss leg requires a trigger price")
+	errOCOSpotOnly          = errors.New("oco orders are only supported for spot")
+	errNoFeeTiers           = errors.New("no fee tiers returned")
+
+	// ErrOrderLimitsUnavailable is returned when a pair has no seeded order
+	// size limits to validate an order against
+	ErrOrderLimitsUnavailable = errors.New("order size limits unavailable")
+)
+
+// depositAddressCache stores resolved deposit addresses per currency so
+// repeated lookups do not create new addresses
+type depositAddressCache struct {
+	m         sync.Mutex
+	addresses map[string]string
+}
+
+func (d *depositAddressCache) get(code string) (string, bool) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	addr, ok := d.addresses[code]
+	return addr, ok
+}
+
+func (d *depositAddressCache) set(code, address string) {
+	d.m.Lock()
+	if d.addresses == nil {
+		d.addresses = make(map[string]string)
+	}
+	d.addresses[code] = address
+	d.m.Unlock()
+}
+
+// withdrawalPrecisions stores the number of decimal places allowed for
+// withdrawals per currency
+type withdrawalPrecisions struct {
+	m          sync.Mutex
+	precisions map[string]int
+}
+
+func (w *withdrawalPrecisions) get(code string) int {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if p, ok := w.precisions[code]; ok {
+		return p
+	}
+	return defaultWithdrawalPrecision
+}
+
+func (w *withdrawalPrecisions) set(code string, precision int) {
+	w.m.Lock()
+	if w.precisions == nil {
+		w.precisions = make(map[string]int)
+	}
+	w.precisions[code] = precision
+	w.m.Unlock()
+}
+
+func (d *depositAddressCache) invalidate(code string) {
+	d.m.Lock()
+	delete(d.addresses, code)
+	d.m.Unlock()
+}
@@ -17,11 +17,16 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
 const (
 	btseWebsocket      = "wss://ws.btse.com/spotWS"
 	btseWebsocketTimer = time.Second * 57
+
+	defaultWsSubscriptionLimit = 50
+	defaultWsSubscriptionDelay = time.Millisecond * 500
+	defaultWsPongTimeout       = time.Second * 30
 )
 
 // WsConnect connects the websocket client
@@ -34,10 +39,12 @@ func (b *BTSE) WsConnect() error {
 	if err != nil {
 		return err
 	}
-	b.Websocket.Conn.SetupPingHandler(stream.PingHandler{
-		MessageType: websocket.PingMessage,
-		Delay:       btseWebsocketTimer,
-	})
+	b.wsPong = make(chan struct{}, 1)
+	if conn, ok := b.Websocket.Conn.(*stream.WebsocketConnection); ok {
+		conn.Connection.SetPongHandler(b.wsHandlePong)
+	}
+	b.Websocket.Wg.Add(1)
+	go b.wsHeartbeat(b.wsPingInterval, b.wsPongTimeout)
 
 	go b.wsReadData()
 	if b.GetAuthenticatedAPISupport(exchange.WebsocketAuthentication) {
@@ -55,6 +62,79 @@ func (b *BTSE) WsConnect() error {
 	return b.Websocket.SubscribeToChannels(subs)
 }
 
+// wsHeartbeat sends a ping every interval so BTSE does not drop idle
+// connections. When a ping cannot be sent or no pong is received within window
+// of a ping the connection is shut down for the connection monitor to
+// re-establish
+func (b *BTSE) wsHeartbeat(interval, window time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(window)
+	deadline.Stop()
+	defer deadline.Stop()
+	var awaitingPong bool
+	for {
+		select {
+		case <-b.Websocket.ShutdownC:
+			b.Websocket.Wg.Done()
+			return
+		case <-b.wsPong:
+			if awaitingPong && !deadline.Stop() {
+				<-deadline.C
+			}
+			awaitingPong = false
+		case <-ticker.C:
+			err := b.Websocket.Conn.SendRawMessage(websocket.PingMessage, nil)
+			if err != nil {
+				log.Errorf(log.WebsocketMgr,
+					"%v websocket: heartbeat failed to send ping: %v. Reconnecting",
+					b.Name,
+					err)
+				b.wsHeartbeatReconnect()
+				return
+			}
+			if !awaitingPong {
+				deadline.Reset(window)
+				awaitingPong = true
+			}
+		case <-deadline.C:
+			log.Warnf(log.WebsocketMgr,
+				"%v websocket: pong not received within %v. Reconnecting",
+				b.Name,
+				window)
+			b.wsHeartbeatReconnect()
+			return
+		}
+	}
+}
+
+// wsHeartbeatReconnect shuts down a connection whose keepalive has failed so
+// the connection monitor re-establishes it
+func (b *BTSE) wsHeartbeatReconnect() {
+	// Released before shutdown as it waits on the websocket routines
+	b.Websocket.Wg.Done()
+	err := b.Websocket.Shutdown()
+	if err != nil {
+		log.Errorf(log.WebsocketMgr,
+			"%v websocket: heartbeat shutdown err: %v",
+			b.Name,
+			err)
+	}
+}
+
+// wsHandlePong notifies the heartbeat of a pong and resets the traffic timer
+func (b *BTSE) wsHandlePong(string) error {
+	select {
+	case b.wsPong <- struct{}{}:
+	default:
+	}
+	select {
+	case b.Websocket.TrafficAlert <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
 // WsAuthenticate Send an authentication message to receive auth data
 func (b *BTSE) WsAuthenticate() error {
 	nonce := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
@@ -123,145 +203,115 @@ func (b *BTSE) wsHandleData(respRaw []byte) error {
 		}
 		return err
 	}
-	switch {
-	case result["topic"] == "notificationApi":
-		var notification wsNotification
-		err = json.Unmarshal(respRaw, &notification)
-		if err != nil {
-			return err
+	topic, _ := result["topic"].(string)
+	handled, err := b.wsRoutes.Route(topic, respRaw)
+	if err != nil {
+		return err
+	}
+	if !handled {
+		b.Websocket.DataHandler <- stream.UnhandledMessageWarning{Message: b.Name + stream.UnhandledMessage + string(respRaw)}
+	}
+	return nil
+}
+
+// newWsRouter registers the handlers for each websocket topic
+func (b *BTSE) newWsRouter() *stream.Router {
+	r := stream.NewRouter()
+	for _, route := range []struct {
+		topic   string
+		handler stream.RouteHandler
+	}{
+		{"notificationApi", b.wsHandleNotification},
+		{"tradeHistory", b.wsHandleTradeHistory},
+		{"orderBookApi", b.wsHandleOrderbook},
+	} {
+		if err := r.Register(route.topic, route.handler); err != nil {
+			log.Errorln(log.ExchangeSys, err)
 		}
-		for i := range notification.Data {
-			var oType order.Type
-			var oSide order.Side
-			var oStatus order.Status
-			oType, err = order.StringToOrderType(notification.Data[i].Type)
-			if err != nil {
-				b.Websocket.DataHandler <- order.ClassificationError{
-					Exchange: b.Name,
-					OrderID:  notification.Data[i].OrderID,
-					Err:      err,
-				}
-			}
-			oSide, err = order.StringToOrderSide(notification.Data[i].OrderMode)
-			if err != nil {
-				b.Websocket.DataHandler <- order.ClassificationError{
-					Exchange: b.Name,
-					OrderID:  notification.Data[i].OrderID,
-					Err:      err,
-				}
-			}
-			oStatus, err = stringToOrderStatus(notification.Data[i].Status)
-			if err != nil {
-				b.Websocket.DataHandler <- order.ClassificationError{
-					Exchange: b.Name,
-					OrderID:  notification.Data[i].OrderID,
-					Err:      err,
-				}
-			}
+	}
+	return r
+}
 
-			var p currency.Pair
-			p, err = currency.NewPairFromString(notification.Data[i].Symbol)
-			if err != nil {
-				return err
+// wsHandleNotification processes order notifications
+func (b *BTSE) wsHandleNotification(_ string, respRaw []byte) error {
+	var notification wsNotification
+	err := json.Unmarshal(respRaw, &notification)
+	if err != nil {
+		return err
+	}
+	for i := range notification.Data {
+		var oType order.Type
+		var oSide order.Side
+		var oStatus order.Status
+		oType, err = order.StringToOrderType(notification.Data[i].Type)
+		if err != nil {
+			b.Websocket.DataHandler <- order.ClassificationError{
+				Exchange: b.Name,
+				OrderID:  notification.Data[i].OrderID,
+				Err:      err,
 			}
-
-			var a asset.Item
-			a, err = b.GetPairAssetType(p)
-			if err != nil {
-				return err
+		}
+		oSide, err = order.StringToOrderSide(notification.Data[i].OrderMode)
+		if err != nil {
+			b.Websocket.DataHandler <- order.ClassificationError{
+				Exchange: b.Name,
+				OrderID:  notification.Data[i].OrderID,
+				Err:      err,
 			}
-
-			b.Websocket.DataHandler <- &order.Detail{
-				Price:        notification.Data[i].Price,
-				Amount:       notification.Data[i].Size,
-				TriggerPrice: notification.Data[i].TriggerPrice,
-				Exchange:     b.Name,
-				ID:           notification.Data[i].OrderID,
-				Type:         oType,
-				Side:         oSide,
-				Status:       oStatus,
-				AssetType:    a,
-				Date:         time.Unix(0, notification.Data[i].Timestamp*int64(time.Millisecond)),
-				Pair:         p,
+		}
+		oStatus, err = stringToOrderStatus(notification.Data[i].Status)
+		if err != nil {
+			b.Websocket.DataHandler <- order.ClassificationError{
+				Exchange: b.Name,
+				OrderID:  notification.Data[i].OrderID,
+				Err:      err,
 			}
 		}
 
-	case strings.Contains(result["topic"].(string), "tradeHistory"):
-		var tradeHistory wsTradeHistory
-		err = json.Unmarshal(respRaw, &tradeHistory)
+		var p currency.Pair
+		p, err = currency.NewPairFromString(notification.Data[i].Symbol)
 		if err != nil {
 			return err
 		}
-		for x := range tradeHistory.Data {
-			side := order.Buy
-			if tradeHistory.Data[x].Gain == -1 {
-				side = order.Sell
-			}
 
-			var p currency.Pair
-			p, err = currency.NewPairFromString(strings.Replace(tradeHistory.Topic,
-				"tradeHistory:",
-				"",
-				1))
-			if err != nil {
-				return err
-			}
-			var a asset.Item
-			a, err = b.GetPairAssetType(p)
-			if err != nil {
-				return err
-			}
-			b.Websocket.DataHandler <- stream.TradeData{
-				Timestamp:    time.Unix(0, tradeHistory.Data[x].TransactionTime*int64(time.Millisecond)),
-				CurrencyPair: p,
-				AssetType:    a,
-				Exchange:     b.Name,
-				Price:        tradeHistory.Data[x].Price,
-				Amount:       tradeHistory.Data[x].Amount,
-				Side:         side,
-			}
-		}
-	case strings.Contains(result["topic"].(string), "orderBookApi"):
-		var t wsOrderBook
-		err = json.Unmarshal(respRaw, &t)
+		var a asset.Item
+		a, err = b.GetPairAssetType(p)
 		if err != nil {
 			return err
 		}
-		var newOB orderbook.Base
-		var price, amount float64
-		for i := range t.Data.SellQuote {
-			p := strings.Replace(t.Data.SellQuote[i].Price, ",", "", -1)
-			price, err = strconv.ParseFloat(p, 64)
-			if err != nil {
-				return err
-			}
-			a := strings.Replace(t.Data.SellQuote[i].Size, ",", "", -1)
-			amount, err = strconv.ParseFloat(a, 64)
-			if err != nil {
-				return err
-			}
-			newOB.Asks = append(newOB.Asks, orderbook.Item{
-				Price:  price,
-				Amount: amount,
-			})
-		}
-		for j := range t.Data.BuyQuote {
-			p := strings.Replace(t.Data.BuyQuote[j].Price, ",", "", -1)
-			price, err = strconv.ParseFloat(p, 64)
-			if err != nil {
-				return err
-			}
-			a := strings.Replace(t.Data.BuyQuote[j].Size, ",", "", -1)
-			amount, err = strconv.ParseFloat(a, 64)
-			if err != nil {
-				return err
-			}
-			newOB.Bids = append(newOB.Bids, orderbook.Item{
-				Price:  price,
-				Amount: amount,
-			})
+
+		b.Websocket.DataHandler <- &order.Detail{
+			Price:        notification.Data[i].Price,
+			Amount:       notification.Data[i].Size,
+			TriggerPrice: notification.Data[i].TriggerPrice,
+			Exchange:     b.Name,
+			ID:           notification.Data[i].OrderID,
+			Type:         oType,
+			Side:         oSide,
+			Status:       oStatus,
+			AssetType:    a,
+			Date:         time.Unix(0, notification.Data[i].Timestamp*int64(time.Millisecond)),
+			Pair:         p,
 		}
-		p, err := currency.NewPairFromString(t.Topic[strings.Index(t.Topic, ":")+1 : strings.Index(t.Topic, "_")])
+	}
+	return nil
+}
+
+// wsHandleTradeHistory processes trade history updates
+func (b *BTSE) wsHandleTradeHistory(_ string, respRaw []byte) error {
+	var tradeHistory wsTradeHistory
+	err := json.Unmarshal(respRaw, &tradeHistory)
+	if err != nil {
+		return err
+	}
+	for x := range tradeHistory.Data {
+		side := tradeSide("", tradeHistory.Data[x].Gain)
+
+		var p currency.Pair
+		p, err = currency.NewPairFromString(strings.Replace(tradeHistory.Topic,
+			"tradeHistory:",
+			"",
+			1))
 		if err != nil {
 			return err
 		}
@@ -270,16 +320,75 @@ func (b *BTSE) wsHandleData(respRaw []byte) error {
 		if err != nil {
 			return err
 		}
-		newOB.Pair = p
-		newOB.AssetType = a
-		newOB.ExchangeName = b.Name
-		err = b.Websocket.Orderbook.LoadSnapshot(&newOB)
+		b.Websocket.DataHandler <- stream.TradeData{
+			Timestamp:    time.Unix(0, tradeHistory.Data[x].TransactionTime*int64(time.Millisecond)),
+			CurrencyPair: p,
+			AssetType:    a,
+			Exchange:     b.Name,
+			Price:        tradeHistory.Data[x].Price,
+			Amount:       tradeHistory.Data[x].Amount,
+			Side:         side,
+		}
+	}
+	return nil
+}
+
+// wsHandleOrderbook processes orderbook snapshots
+func (b *BTSE) wsHandleOrderbook(_ string, respRaw []byte) error {
+	var t wsOrderBook
+	err := json.Unmarshal(respRaw, &t)
+	if err != nil {
+		return err
+	}
+	var newOB orderbook.Base
+	var price, amount float64
+	for i := range t.Data.SellQuote {
+		p := strings.Replace(t.Data.SellQuote[i].Price, ",", "", -1)
+		price, err = strconv.ParseFloat(p, 64)
+		if err != nil {
+			return err
+		}
+		a := strings.Replace(t.Data.SellQuote[i].Size, ",", "", -1)
+		amount, err = strconv.ParseFloat(a, 64)
 		if err != nil {
 			return err
 		}
-	default:
-		b.Websocket.DataHandler <- stream.UnhandledMessageWarning{Message: b.Name + stream.UnhandledMessage + string(respRaw)}
-		return nil
+		newOB.Asks = append(newOB.Asks, orderbook.Item{
+			Price:  price,
+			Amount: amount,
+		})
+	}
+	for j := range t.Data.BuyQuote {
+		p := strings.Replace(t.Data.BuyQuote[j].Price, ",", "", -1)
+		price, err = strconv.ParseFloat(p, 64)
+		if err != nil {
+			return err
+		}
+		a := strings.Replace(t.Data.BuyQuote[j].Size, ",", "", -1)
+		amount, err = strconv.ParseFloat(a, 64)
+		if err != nil {
+			return err
+		}
+		newOB.Bids = append(newOB.Bids, orderbook.Item{
+			Price:  price,
+			Amount: amount,
+		})
+	}
+	p, err := currency.NewPairFromString(t.Topic[strings.Index(t.Topic, ":")+1 : strings.Index(t.Topic, "_")])
+	if err != nil {
+		return err
+	}
+	var a asset.Item
+	a, err = b.GetPairAssetType(p)
+	if err != nil {
+		return err
+	}
+	newOB.Pair = p
+	newOB.AssetType = a
+	newOB.ExchangeName = b.Name
+	err = b.Websocket.Orderbook.LoadSnapshot(&newOB)
+	if err != nil {
+		return err
 	}
 	return nil
 }
@@ -309,21 +418,43 @@ func (b *BTSE) GenerateDefaultSubscriptions() ([]stream.ChannelSubscription, err
 	return subscriptions, nil
 }
 
-// Subscribe sends a websocket message to receive data from the channel
+// Subscribe sends a websocket message to receive data from the channel,
+// channels are sent in batches of at most wsSubscriptionLimit with a pause
+// between each batch so large subscriptions do not get the connection dropped
 func (b *BTSE) Subscribe(channelsToSubscribe []stream.ChannelSubscription) error {
-	var sub wsSub
-	sub.Operation = "subscribe"
-	for i := range channelsToSubscribe {
-		sub.Arguments = append(sub.Arguments, channelsToSubscribe[i].Channel)
-	}
-	err := b.Websocket.Conn.SendJSONMessage(sub)
-	if err != nil {
-		return err
+	batches := batchSubscriptions(channelsToSubscribe, b.wsSubscriptionLimit)
+	for x := range batches {
+		if x > 0 {
+			time.Sleep(b.wsSubscriptionDelay)
+		}
+		var sub wsSub
+		sub.Operation = "subscribe"
+		for i := range batches[x] {
+			sub.Arguments = append(sub.Arguments, batches[x][i].Channel)
+		}
+		err := b.Websocket.Conn.SendJSONMessage(sub)
+		if err != nil {
+			return err
+		}
+		b.Websocket.AddSuccessfulSubscriptions(batches[x]...)
 	}
-	b.Websocket.AddSuccessfulSubscriptions(channelsToSubscribe...)
 	return nil
 }
 
+// batchSubscriptions splits subscriptions into groups of at most limit
+// channels, a limit of zero or less returns a single group
+func batchSubscriptions(subs []stream.ChannelSubscription, limit int) [][]stream.ChannelSubscription {
+	if limit <= 0 || len(subs) <= limit {
+		return [][]stream.ChannelSubscription{subs}
+	}
+	var batches [][]stream.ChannelSubscription
+	for len(subs) > limit {
+		batches = append(batches, subs[:limit])
+		subs = subs[limit:]
+	}
+	return append(batches, subs)
+}
+
 // Unsubscribe sends a websocket message to stop receiving data from the channel
 func (b *BTSE) Unsubscribe(channelsToUnsubscribe []stream.ChannelSubscription) error {
 	var unSub wsSub
@@ -339,3 +470,13 @@ func (b *BTSE) Unsubscribe(channelsToUnsubscribe []stream.ChannelSubscription) e
 	b.Websocket.RemoveSuccessfulUnsubscriptions(channelsToUnsubscribe...)
 	return nil
 }
+
+// UnsubscribeAll unsubscribes from every active channel and clears the
+// tracked subscriptions, it is a no-op when nothing is subscribed
+func (b *BTSE) UnsubscribeAll() error {
+	subs := b.Websocket.GetSubscriptions()
+	if len(subs) == 0 {
+		return nil
+	}
+	return b.Unsubscribe(subs)
+}
@@ -152,9 +152,14 @@ func (b *BTSE) SetDefaults() {
 	b.API.Endpoints.URLDefault = btseAPIURL
 	b.API.Endpoints.URL = b.API.Endpoints.URLDefault
 	b.Websocket = stream.New()
+	b.wsRoutes = b.newWsRouter()
 	b.WebsocketResponseMaxLimit = exchange.DefaultWebsocketResponseMaxLimit
 	b.WebsocketResponseCheckTimeout = exchange.DefaultWebsocketResponseCheckTimeout
 	b.WebsocketOrderbookBufferLimit = exchange.DefaultWebsocketOrderbookBufferLimit
+	b.wsSubscriptionLimit = defaultWsSubscriptionLimit
+	b.wsSubscriptionDelay = defaultWsSubscriptionDelay
+	b.wsPingInterval = btseWebsocketTimer
+	b.wsPongTimeout = defaultWsPongTimeout
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -169,6 +174,16 @@ func (b *BTSE) Setup(exch *config.ExchangeConfig) error {
 		return err
 	}
 
+	if exch.WebsocketSubscriptionLimit > 0 {
+		b.wsSubscriptionLimit = exch.WebsocketSubscriptionLimit
+	}
+	if exch.WebsocketPingInterval > 0 {
+		b.wsPingInterval = exch.WebsocketPingInterval
+	}
+	if exch.WebsocketTrafficTimeout > 0 {
+		b.wsPongTimeout = exch.WebsocketTrafficTimeout
+	}
+
 	err = b.Websocket.Setup(&stream.WebsocketSetup{
 		Enabled:                          exch.Features.Enabled.Websocket,
 		Verbose:                          exch.Verbose,
@@ -220,8 +235,7 @@ func (b *BTSE) Run() {
 
 	err := b.UpdateTradablePairs(false)
 	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s Failed to update tradable pairs. Error: %s", b.Name, err)
+		b.logError("Run", currency.Pair{}, "", err)
 	}
 }
 
@@ -267,15 +281,65 @@ func (b *BTSE) UpdateTradablePairs(forceUpdate bool) error {
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *BTSE) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickers, err := b.GetMarketSummary("", assetType == asset.Spot)
+	err := b.updateTickers(assetType)
 	if err != nil {
 		return nil, err
 	}
+	return ticker.GetTicker(b.Name, p, assetType)
+}
+
+// UpdateTickers updates the tickers for all supported asset types from a
+// single batch of market summaries
+func (b *BTSE) UpdateTickers() error {
+	batch, err := b.GetTickerBatch()
+	if err != nil {
+		return err
+	}
+	for a, tickers := range batch {
+		err = b.processTickers(tickers, a)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTickerBatch fetches the market summaries for every supported asset type,
+// one request per asset made concurrently through the exchange requester, and
+// returns them grouped by asset type
+func (b *BTSE) GetTickerBatch() (map[asset.Item]MarketSummary, error) {
+	assets := b.GetAssetTypes()
+	summaries := make([]MarketSummary, len(assets))
+	err := b.Requester.FanOut(len(assets), len(assets), func(i int) error {
+		var err error
+		summaries[i], err = b.GetMarketSummary("", assets[i] == asset.Spot)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	batch := make(map[asset.Item]MarketSummary, len(assets))
+	for i := range assets {
+		batch[assets[i]] = summaries[i]
+	}
+	return batch, nil
+}
+
+// updateTickers fetches and processes every ticker for an asset type
+func (b *BTSE) updateTickers(assetType asset.Item) error {
+	tickers, err := b.GetMarketSummary("", assetType == asset.Spot)
+	if err != nil {
+		return err
+	}
+	return b.processTickers(tickers, assetType)
+}
+
+// processTickers stores the tickers from an asset type's market summary
+func (b *BTSE) processTickers(tickers MarketSummary, assetType asset.Item) error {
 	for x := range tickers {
-		var pair currency.Pair
-		pair, err = currency.NewPairFromString(tickers[x].Symbol)
+		pair, err := currency.NewPairFromString(tickers[x].Symbol)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		err = ticker.ProcessTicker(&ticker.Price{
@@ -289,11 +353,33 @@ func (b *BTSE) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 			ExchangeName: b.Name,
 			AssetType:    assetType})
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
+	return nil
+}
 
-	return ticker.GetTicker(b.Name, p, assetType)
+// GetOpenInterest returns the open interest in contracts for a futures pair
+func (b *BTSE) GetOpenInterest(pair currency.Pair) (float64, error) {
+	fPair, err := b.FormatExchangeCurrency(pair, asset.Futures)
+	if err != nil {
+		return 0, err
+	}
+	m, err := b.GetMarketSummary(fPair.String(), false)
+	if err != nil {
+		return 0, err
+	}
+	return openInterest(m, fPair.String())
+}
+
+// openInterest returns the open interest for a symbol from a market summary
+func openInterest(m MarketSummary, symbol string) (float64, error) {
+	for x := range m {
+		if strings.EqualFold(m[x].Symbol, symbol) {
+			return m[x].OpenInterest, nil
+		}
+	}
+	return 0, fmt.Errorf("%s %w", symbol, errMarketNotFound)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -349,27 +435,22 @@ func (b *BTSE) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderboo
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
 // BTSE exchange
 func (b *BTSE) UpdateAccountInfo() (account.Holdings, error) {
-	var a account.Holdings
 	balance, err := b.GetWalletInformation()
 	if err != nil {
-		return a, err
+		return account.Holdings{}, err
 	}
 
-	var currencies []account.Balance
-	for b := range balance {
-		currencies = append(currencies,
-			account.Balance{
-				CurrencyName: currency.NewCode(balance[b].Currency),
-				TotalValue:   balance[b].Total,
-				Hold:         balance[b].Available,
-			},
-		)
+	var futures []FuturesWallet
+	if b.CurrencyPairs.IsAssetEnabled(asset.Futures) == nil {
+		futures, err = b.GetFuturesWallets()
+		if err != nil {
+			return account.Holdings{}, err
+		}
 	}
-	a.Exchange = b.Name
-	a.Accounts = []account.SubAccount{
-		{
-			Currencies: currencies,
-		},
+
+	a := account.Holdings{
+		Exchange: b.Name,
+		Accounts: walletSubAccounts(balance, futures),
 	}
 
 	err = account.Process(&a)
@@ -380,6 +461,58 @@ func (b *BTSE) UpdateAccountInfo() (account.Holdings, error) {
 	return a, nil
 }
 
+// spotWalletID identifies the spot wallet sub account when futures wallets
+// are also reported
+const spotWalletID = "SPOT@"
+
+// walletSubAccounts builds a sub account for the spot wallet and one for each
+// futures wallet keyed by its wallet name. Without futures wallets a single
+// unnamed sub account holds the spot balances
+func walletSubAccounts(spot []CurrencyBalance, futures []FuturesWallet) []account.SubAccount {
+	spotAccount := account.SubAccount{}
+	for x := range spot {
+		spotAccount.Currencies = append(spotAccount.Currencies,
+			account.Balance{
+				CurrencyName: normaliseCurrency(spot[x].Currency),
+				TotalValue:   spot[x].Total,
+				Hold:         spot[x].Available,
+			},
+		)
+	}
+	if len(futures) == 0 {
+		return []account.SubAccount{spotAccount}
+	}
+	spotAccount.ID = spotWalletID
+	subAccounts := []account.SubAccount{spotAccount}
+	for x := range futures {
+		sub := account.SubAccount{ID: futures[x].Wallet}
+		for y := range futures[x].Assets {
+			sub.Currencies = append(sub.Currencies, account.Balance{
+				CurrencyName: normaliseCurrency(futures[x].Assets[y].Currency),
+				TotalValue:   futures[x].Assets[y].Balance,
+			})
+		}
+		subAccounts = append(subAccounts, sub)
+	}
+	return subAccounts
+}
+
+// currencyAliases are exchange specific codes which are translated to their
+// common code when building holdings
+var currencyAliases = []currency.Code{currency.XBT, currency.XETH, currency.XDG}
+
+// normaliseCurrency returns the common currency code for a BTSE currency,
+// aliases such as XBT are translated to BTC
+func normaliseCurrency(c string) currency.Code {
+	code := currency.NewCode(c)
+	for x := range currencyAliases {
+		if code.Match(currencyAliases[x]) {
+			return currency.GetTranslation(currencyAliases[x])
+		}
+	}
+	return code
+}
+
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *BTSE) FetchAccountInfo() (account.Holdings, error) {
 	acc, err := account.GetHoldings(b.Name)
@@ -393,13 +526,59 @@ func (b *BTSE) FetchAccountInfo() (account.Holdings, error) {
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *BTSE) GetFundingHistory() ([]exchange.FundHistory, error) {
-	return nil, common.ErrFunctionNotSupported
+	h, err := b.GetWalletHistory("", time.Time{}, time.Time{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return b.fundHistory(h, false), nil
+}
+
+// GetDepositHistory returns the deposit history for a currency, an empty
+// currency returns deposits for all currencies
+func (b *BTSE) GetDepositHistory(c currency.Code) ([]exchange.FundHistory, error) {
+	var symbol string
+	if !c.IsEmpty() {
+		symbol = c.Upper().String()
+	}
+	h, err := b.GetWalletHistory(symbol, time.Time{}, time.Time{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return b.fundHistory(h, true), nil
+}
+
+// fundHistory converts wallet history records into funding history,
+// optionally keeping only deposits
+func (b *BTSE) fundHistory(h WalletHistory, depositsOnly bool) []exchange.FundHistory {
+	var resp []exchange.FundHistory
+	for x := range h {
+		if depositsOnly && !strings.EqualFold(h[x].Type, walletDepositType) {
+			continue
+		}
+		resp = append(resp, exchange.FundHistory{
+			ExchangeName: b.Name,
+			Status:       h[x].Status,
+			TransferID:   h[x].OrderID,
+			Description:  h[x].Description,
+			Timestamp:    time.Unix(0, h[x].Timestamp*int64(time.Millisecond)),
+			Currency:     normaliseCurrency(h[x].Currency).String(),
+			Amount:       h[x].Amount,
+			Fee:          h[x].Fees,
+			TransferType: h[x].Type,
+		})
+	}
+	return resp
 }
 
 // GetExchangeHistory returns historic trade data within the timeframe provided.
 func (b *BTSE) GetExchangeHistory(p currency.Pair, assetType asset.Item, timestampStart, timestampEnd time.Time) ([]exchange.TradeHistory, error) {
-	if assetType != asset.Spot {
+	switch assetType {
+	case asset.Spot:
+	case asset.Futures:
+		// BTSE offers futures trade history, it has not been wired up yet
 		return nil, common.ErrNotYetImplemented
+	default:
+		return nil, fmt.Errorf("%w: asset %v", common.ErrFunctionNotSupported, assetType)
 	}
 
 	fPair, err := b.FormatExchangeCurrency(p, assetType)
@@ -421,7 +600,7 @@ func (b *BTSE) GetExchangeHistory(p currency.Pair, assetType asset.Item, timesta
 			Price:     trades[x].Price,
 			Amount:    trades[x].Amount,
 			Exchange:  b.Name,
-			Side:      trades[x].Side,
+			Side:      tradeSide(trades[x].Side, 0).String(),
 			Type:      trades[x].Type,
 			TID:       strconv.Itoa(trades[x].SerialID),
 		}
@@ -431,6 +610,26 @@ func (b *BTSE) GetExchangeHistory(p currency.Pair, assetType asset.Item, timesta
 	return resp, nil
 }
 
+// tradeSide normalises a BTSE trade side, when the side is missing or not
+// recognised it is inferred from the tick direction and is otherwise unknown
+func tradeSide(side string, gain int64) order.Side {
+	s, err := order.StringToOrderSide(strings.TrimSpace(side))
+	switch {
+	case err == nil && (s == order.Buy || s == order.Sell):
+		return s
+	case err == nil && s == order.Bid:
+		return order.Buy
+	case err == nil && s == order.Ask:
+		return order.Sell
+	case gain > 0:
+		return order.Buy
+	case gain < 0:
+		return order.Sell
+	default:
+		return order.UnknownSide
+	}
+}
+
 func (b *BTSE) withinLimits(pair currency.Pair, amount float64) bool {
 	val, found := OrderSizeLimits(pair.String())
 	if !found {
@@ -448,34 +647,195 @@ func (b *BTSE) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
 		return resp, err
 	}
 
+	if s.PostOnly && s.Type == order.Market {
+		return resp, errPostOnlyMarketOrder
+	}
+
+	if s.ReduceOnly && s.AssetType != asset.Futures {
+		return resp, errReduceOnlyNotFutures
+	}
+
 	fPair, err := b.FormatExchangeCurrency(s.Pair, s.AssetType)
 	if err != nil {
 		return resp, err
 	}
-	inLimits := b.withinLimits(fPair, s.Amount)
+	limits, found := OrderSizeLimits(fPair.String())
+	if !found || limits == (OrderSizeLimit{}) {
+		return resp, fmt.Errorf("%s %w", fPair, ErrOrderLimitsUnavailable)
+	}
+	// Futures are sized in contracts while the submission is in base units
+	amount := s.Amount
+	if s.AssetType == asset.Futures {
+		amount, err = order.ContractsFromAmount(s.Amount, limits.ContractSize)
+		if err != nil {
+			return resp, fmt.Errorf("%s %w", fPair, err)
+		}
+	}
+	inLimits := b.withinLimits(fPair, amount)
 	if !inLimits {
 		return resp, errors.New("order outside of limits")
 	}
 
-	r, err := b.CreateOrder(s.ClientID, 0.0,
+	var r []Order
+	if s.AssetType == asset.Futures {
+		r, err = b.CreateFuturesOrder(s.ClientID,
+			s.PostOnly, s.ReduceOnly,
+			s.Price, s.Side.String(), amount,
+			fPair.String(), goodTillCancel,
+			s.TriggerPrice, s.Type.String())
+	} else {
+		r, err = b.CreateOrder(s.ClientID, 0.0,
+			s.PostOnly,
+			s.Price, s.Side.String(), s.Amount, 0, 0,
+			fPair.String(), goodTillCancel,
+			0.0, s.TriggerPrice,
+			"", s.Type.String())
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	resp, err = submitResponse(r, amount)
+	if s.AssetType == asset.Futures {
+		// Fills are reported in contracts, the contract size was validated
+		// above so the conversion cannot fail
+		resp.FilledAmount, _ = order.AmountFromContracts(resp.FilledAmount,
+			limits.ContractSize)
+	}
+	return resp, err
+}
+
+// SubmitOCOOrder submits a take profit limit leg and a stop loss leg as a
+// linked one-cancels-other order. Both legs close the same position so they
+// must share pair, side and amount, with the take profit priced beyond the
+// stop loss trigger in the direction of the order side
+func (b *BTSE) SubmitOCOOrder(takeProfit, stopLoss *order.Submit) (OCOResponse, error) {
+	if err := validateOCOLegs(takeProfit, stopLoss); err != nil {
+		return OCOResponse{}, err
+	}
+
+	fPair, err := b.FormatExchangeCurrency(takeProfit.Pair, takeProfit.AssetType)
+	if err != nil {
+		return OCOResponse{}, err
+	}
+
+	r, err := b.CreateOrder(takeProfit.ClientID, 0.0,
 		false,
-		s.Price, s.Side.String(), s.Amount, 0, 0,
+		takeProfit.Price, takeProfit.Side.String(), takeProfit.Amount, 0,
+		stopLoss.Price,
 		fPair.String(), goodTillCancel,
-		0.0, s.TriggerPrice,
-		"", s.Type.String())
+		0.0, stopLoss.TriggerPrice,
+		"", ocoOrder)
 	if err != nil {
-		return resp, err
+		return OCOResponse{}, err
 	}
 
-	resp.IsOrderPlaced = true
-	resp.OrderID = r[0].OrderID
+	var resp OCOResponse
+	for x := range r {
+		if r[x].Trigger {
+			resp.StopLossOrderID = r[x].OrderID
+		} else {
+			resp.TakeProfitOrderID = r[x].OrderID
+		}
+	}
+	if resp.TakeProfitOrderID == "" || resp.StopLossOrderID == "" {
+		return resp, errNoOrderResponse
+	}
+	return resp, nil
+}
 
-	if s.Type == order.Market {
-		resp.FullyMatched = true
+// validateOCOLegs ensures the take profit is a valid limit order and the
+// stop loss mirrors it with a trigger on the other side of the market
+func validateOCOLegs(takeProfit, stopLoss *order.Submit) error {
+	if err := takeProfit.Validate(); err != nil {
+		return err
+	}
+	if takeProfit.Type != order.Limit {
+		return order.ErrTypeIsInvalid
+	}
+	if stopLoss == nil {
+		return order.ErrSubmissionIsNil
+	}
+	if takeProfit.AssetType != asset.Spot || stopLoss.AssetType != asset.Spot {
+		return errOCOSpotOnly
+	}
+	if !takeProfit.Pair.Equal(stopLoss.Pair) ||
+		takeProfit.Side != stopLoss.Side ||
+		takeProfit.Amount != stopLoss.Amount {
+		return errOCOLegMismatch
+	}
+	if stopLoss.TriggerPrice <= 0 {
+		return errOCOStopTriggerNotSet
+	}
+
+	switch takeProfit.Side {
+	case order.Sell, order.Ask:
+		if takeProfit.Price <= stopLoss.TriggerPrice {
+			return errOCOLegsSameSide
+		}
+	default:
+		if takeProfit.Price >= stopLoss.TriggerPrice {
+			return errOCOLegsSameSide
+		}
+	}
+	return nil
+}
+
+// submitResponse converts a create order response into a submit response,
+// summing the fills returned as a market order may only partially fill
+// against a thin book. A rejected order is returned alongside an error
+// carrying the exchange's rejection reason
+func submitResponse(r []Order, amount float64) (order.SubmitResponse, error) {
+	if len(r) == 0 {
+		return order.SubmitResponse{}, errNoOrderResponse
+	}
+	resp := order.SubmitResponse{
+		IsOrderPlaced: true,
+		OrderID:       r[0].OrderID,
+		Status:        orderStatusCodeToStatus(r[len(r)-1].Status),
+	}
+	for x := range r {
+		resp.FilledAmount += r[x].FillSize
+	}
+	resp.FullyMatched = amount > 0 && resp.FilledAmount >= amount
+
+	switch resp.Status {
+	case order.Rejected, order.InsufficientBalance, order.MarketUnavailable:
+		resp.IsOrderPlaced = false
+		resp.RejectReason = r[len(r)-1].Message
+		if resp.RejectReason == "" {
+			resp.RejectReason = resp.Status.String()
+		}
+		return resp, fmt.Errorf("%s %w: %s", resp.OrderID, errOrderRejected, resp.RejectReason)
 	}
 	return resp, nil
 }
 
+// orderStatusCodeToStatus converts a numeric BTSE create order status into
+// a standard order status
+func orderStatusCodeToStatus(status int) order.Status {
+	switch status {
+	case 1:
+		return order.MarketUnavailable
+	case 2, 9:
+		return order.New
+	case 4:
+		return order.Filled
+	case 5:
+		return order.PartiallyFilled
+	case 6, 7:
+		return order.Cancelled
+	case 8:
+		return order.InsufficientBalance
+	case 10:
+		return order.Active
+	case 15:
+		return order.Rejected
+	default:
+		return order.UnknownStatus
+	}
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *BTSE) ModifyOrder(action *order.Modify) (string, error) {
@@ -484,6 +844,11 @@ func (b *BTSE) ModifyOrder(action *order.Modify) (string, error) {
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *BTSE) CancelOrder(order *order.Cancel) error {
+	// BTSE cancels every order for the symbol when neither ID is supplied
+	if order.ID == "" && order.ClientOrderID == "" {
+		return errOrderIDNotSet
+	}
+
 	fPair, err := b.FormatExchangeCurrency(order.Pair,
 		order.AssetType)
 	if err != nil {
@@ -563,10 +928,8 @@ func (b *BTSE) GetOrderInfo(orderID string) (order.Detail, error) {
 		od.Pair, err = currency.NewPairDelimiter(o[i].Symbol,
 			format.Delimiter)
 		if err != nil {
-			log.Errorf(log.ExchangeSys,
-				"%s GetOrderInfo unable to parse currency pair: %s\n",
-				b.Name,
-				err)
+			b.logError("GetOrderInfo", currency.Pair{}, asset.Spot,
+				fmt.Errorf("unable to parse currency pair: %w", err))
 		}
 		od.Exchange = b.Name
 		od.Amount = o[i].Size
@@ -593,8 +956,8 @@ func (b *BTSE) GetOrderInfo(orderID string) (order.Detail, error) {
 		for i := range th {
 			createdAt, err := parseOrderTime(th[i].TradeID)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
-					"%s GetOrderInfo unable to parse time: %s\n", b.Name, err)
+				b.logError("GetOrderInfo", od.Pair, asset.Spot,
+					fmt.Errorf("unable to parse time: %w", err))
 			}
 			od.Trades = append(od.Trades, order.TradeHistory{
 				Timestamp: createdAt,
@@ -612,27 +975,41 @@ func (b *BTSE) GetOrderInfo(orderID string) (order.Detail, error) {
 
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *BTSE) GetDepositAddress(cryptocurrency currency.Code, accountID string) (string, error) {
+	code := cryptocurrency.Upper().String()
+	if addr, ok := b.depositAddresses.get(code); ok {
+		return addr, nil
+	}
 	address, err := b.GetWalletAddress(cryptocurrency.String())
 	if err != nil {
 		return "", err
 	}
 	if len(address) == 0 {
-		addressCreate, err := b.CreateWalletAddress(cryptocurrency.String())
+		address, err = b.CreateWalletAddress(cryptocurrency.String())
 		if err != nil {
 			return "", err
 		}
-		if len(addressCreate) != 0 {
-			return addressCreate[0].Address, nil
+		if len(address) == 0 {
+			return "", errors.New("address not found")
 		}
-		return "", errors.New("address not found")
 	}
+	b.depositAddresses.set(code, address[0].Address)
 	return address[0].Address, nil
 }
 
+// InvalidateDepositAddress removes a cached deposit address so the next
+// GetDepositAddress call fetches it from the exchange
+func (b *BTSE) InvalidateDepositAddress(cryptocurrency currency.Code) {
+	b.depositAddresses.invalidate(cryptocurrency.Upper().String())
+}
+
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *BTSE) WithdrawCryptocurrencyFunds(withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error) {
-	amountToString := strconv.FormatFloat(withdrawRequest.Amount, 'f', 8, 64)
+	amountToString, err := formatWithdrawalAmount(withdrawRequest.Amount,
+		b.withdrawPrecision.get(withdrawRequest.Currency.Upper().String()))
+	if err != nil {
+		return nil, err
+	}
 	resp, err := b.WalletWithdrawal(withdrawRequest.Currency.String(),
 		withdrawRequest.Crypto.Address,
 		withdrawRequest.Crypto.AddressTag,
@@ -646,6 +1023,36 @@ func (b *BTSE) WithdrawCryptocurrencyFunds(withdrawRequest *withdraw.Request) (*
 	}, nil
 }
 
+// SetWithdrawalPrecision sets the number of decimal places a currency's
+// withdrawal amount is rounded down to, currencies not set default to 8
+func (b *BTSE) SetWithdrawalPrecision(c currency.Code, precision int) error {
+	if precision < 0 {
+		return fmt.Errorf("%s %w", c, errInvalidPrecision)
+	}
+	b.withdrawPrecision.set(c.Upper().String(), precision)
+	return nil
+}
+
+// formatWithdrawalAmount rounds the amount down to the supplied number of
+// decimal places so the exchange does not reject it for excess precision
+func formatWithdrawalAmount(amount float64, precision int) (string, error) {
+	s := strconv.FormatFloat(amount, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		if len(s)-i-1 > precision {
+			s = s[:i+1+precision]
+		}
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	rounded, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return "", err
+	}
+	if rounded <= 0 {
+		return "", fmt.Errorf("%v %w of %d", amount, errWithdrawAmountZero, precision)
+	}
+	return s, nil
+}
+
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *BTSE) WithdrawFiatFunds(withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error) {
@@ -660,13 +1067,18 @@ func (b *BTSE) WithdrawFiatFundsToInternationalBank(withdrawRequest *withdraw.Re
 
 // GetActiveOrders retrieves any orders that are active/open
 func (b *BTSE) GetActiveOrders(req *order.GetOrdersRequest) ([]order.Detail, error) {
-	if len(req.Pairs) == 0 {
-		return nil, errors.New("no pair provided")
+	pairs := req.Pairs
+	if len(pairs) == 0 {
+		var err error
+		pairs, err = b.GetEnabledPairs(asset.Spot)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var orders []order.Detail
-	for x := range req.Pairs {
-		formattedPair, err := b.FormatExchangeCurrency(req.Pairs[x], asset.Spot)
+	for x := range pairs {
+		formattedPair, err := b.FormatExchangeCurrency(pairs[x], asset.Spot)
 		if err != nil {
 			return nil, err
 		}
@@ -689,10 +1101,8 @@ func (b *BTSE) GetActiveOrders(req *order.GetOrdersRequest) ([]order.Detail, err
 			p, err := currency.NewPairDelimiter(resp[i].Symbol,
 				format.Delimiter)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
-					"%s GetActiveOrders unable to parse currency pair: %s\n",
-					b.Name,
-					err)
+				b.logError("GetActiveOrders", pairs[x], asset.Spot,
+					fmt.Errorf("unable to parse currency pair: %w", err))
 			}
 
 			openOrder := order.Detail{
@@ -719,20 +1129,18 @@ func (b *BTSE) GetActiveOrders(req *order.GetOrdersRequest) ([]order.Detail, err
 				false,
 				"", resp[i].OrderID)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
-					"%s: Unable to get order fills for orderID %s",
-					b.Name,
-					resp[i].OrderID)
+				b.logError("GetActiveOrders", p, asset.Spot,
+					fmt.Errorf("unable to get order fills for orderID %s: %w",
+						resp[i].OrderID,
+						err))
 				continue
 			}
 
 			for i := range fills {
 				createdAt, err := parseOrderTime(fills[i].Timestamp)
 				if err != nil {
-					log.Errorf(log.ExchangeSys,
-						"%s GetActiveOrders unable to parse time: %s\n",
-						b.Name,
-						err)
+					b.logError("GetActiveOrders", p, asset.Spot,
+						fmt.Errorf("unable to parse time: %w", err))
 				}
 				openOrder.Trades = append(openOrder.Trades, order.TradeHistory{
 					Timestamp: createdAt,
@@ -837,11 +1245,16 @@ func (b *BTSE) GetHistoricCandles(pair currency.Pair, a asset.Item, start, end t
 		return kline.Item{}, err
 	}
 
+	if kline.TotalCandlesPerInterval(start, end, interval) > b.Features.Enabled.Kline.GetResultLimit(a) {
+		return kline.Item{}, errors.New(kline.ErrRequestExceedsExchangeLimits)
+	}
+
 	fPair, err := b.FormatExchangeCurrency(pair, a)
 	if err != nil {
 		return kline.Item{}, err
 	}
-	intervalInt, err := strconv.Atoi(b.FormatExchangeKlineInterval(interval))
+
+	candles, err := b.fetchCandles(fPair, a, start, end, interval)
 	if err != nil {
 		return kline.Item{}, err
 	}
@@ -851,61 +1264,93 @@ func (b *BTSE) GetHistoricCandles(pair currency.Pair, a asset.Item, start, end t
 		Pair:     fPair,
 		Asset:    a,
 		Interval: interval,
+		Candles:  candles,
 	}
+	klineRet.SortCandlesByTimestamp(false)
+	return klineRet, nil
+}
 
-	switch a {
-	case asset.Spot:
-		req, err := b.OHLCV(fPair.String(),
-			start,
-			end,
-			intervalInt)
-		if err != nil {
-			return kline.Item{}, err
-		}
-		for x := range req {
-			klineRet.Candles = append(klineRet.Candles, kline.Candle{
-				Time:   time.Unix(int64(req[x][0]), 0),
-				Open:   req[x][1],
-				High:   req[x][2],
-				Low:    req[x][3],
-				Close:  req[x][4],
-				Volume: req[x][5],
-			})
+// GetRateLimitStatus returns the state of the query and order rate limit
+// buckets
+func (b *BTSE) GetRateLimitStatus() (map[string]request.LimitStatus, error) {
+	return b.Requester.GetRateLimitStatus()
+}
+
+// GetTradingStatus returns whether BTSE is accepting orders. BTSE flags each
+// market inactive during maintenance, so the spot market summary is used as
+// the status source and cached for DefaultTradingStatusTTL
+func (b *BTSE) GetTradingStatus() (exchange.TradingStatus, error) {
+	return b.tradingStatus.Get(exchange.DefaultTradingStatusTTL,
+		func() (exchange.TradingStatus, error) {
+			m, err := b.GetMarketSummary("", true)
+			if err != nil {
+				return exchange.TradingStatusUnknown, err
+			}
+			return tradingStatusFromMarkets(m), nil
+		})
+}
+
+// tradingStatusFromMarkets maps a market summary to a trading status, the
+// exchange is treated as under maintenance when no market is active
+func tradingStatusFromMarkets(m MarketSummary) exchange.TradingStatus {
+	if len(m) == 0 {
+		return exchange.TradingStatusUnknown
+	}
+	for x := range m {
+		if m[x].Active {
+			return exchange.TradingStatusOpen
 		}
-	case asset.Futures:
-		return kline.Item{}, common.ErrNotYetImplemented
-	default:
-		return kline.Item{}, fmt.Errorf("asset %v not supported", a.String())
 	}
+	return exchange.TradingStatusMaintenance
+}
 
-	klineRet.SortCandlesByTimestamp(false)
-	return klineRet, nil
+// GetLatestCandle returns the most recent closed candle for a pair, the
+// candle still in progress is ignored
+func (b *BTSE) GetLatestCandle(pair currency.Pair, a asset.Item, interval kline.Interval) (kline.Candle, error) {
+	now := time.Now()
+	start := kline.TruncateInLocation(now, interval, nil).
+		Add(-interval.Duration() * kline.LatestCandleLookback)
+	item, err := b.GetHistoricCandles(pair, a, start, now, interval)
+	if err != nil {
+		return kline.Candle{}, err
+	}
+	return kline.LatestClosedCandle(item.Candles, interval, now)
 }
 
-// GetHistoricCandlesExtended returns candles between a time period for a set time interval
+// GetHistoricCandlesExtended returns candles between a time period for a set
+// time interval, paging requests which exceed the exchange result limit
 func (b *BTSE) GetHistoricCandlesExtended(pair currency.Pair, a asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
 	if err := b.ValidateKline(pair, a, interval); err != nil {
 		return kline.Item{}, err
 	}
 
-	if kline.TotalCandlesPerInterval(start, end, interval) > b.Features.Enabled.Kline.ResultLimit {
-		return kline.Item{}, errors.New(kline.ErrRequestExceedsExchangeLimits)
-	}
-
 	fPair, err := b.FormatExchangeCurrency(pair, a)
 	if err != nil {
 		return kline.Item{}, err
 	}
-	intervalInt, err := strconv.Atoi(b.FormatExchangeKlineInterval(interval))
+
+	candles, err := b.Features.Enabled.Kline.PaginateCandles(a, start, end, interval,
+		func(start, end time.Time) ([]kline.Candle, error) {
+			return b.fetchCandles(fPair, a, start, end, interval)
+		})
 	if err != nil {
 		return kline.Item{}, err
 	}
 
-	klineRet := kline.Item{
+	return kline.Item{
 		Exchange: b.Name,
 		Pair:     fPair,
 		Asset:    a,
 		Interval: interval,
+		Candles:  candles,
+	}, nil
+}
+
+// fetchCandles retrieves candles for a single request
+func (b *BTSE) fetchCandles(fPair currency.Pair, a asset.Item, start, end time.Time, interval kline.Interval) ([]kline.Candle, error) {
+	intervalInt, err := strconv.Atoi(b.FormatExchangeKlineInterval(interval))
+	if err != nil {
+		return nil, err
 	}
 
 	switch a {
@@ -915,26 +1360,26 @@ func (b *BTSE) GetHistoricCandlesExtended(pair currency.Pair, a asset.Item, star
 			end,
 			intervalInt)
 		if err != nil {
-			return kline.Item{}, err
+			return nil, err
 		}
+		candles := make([]kline.Candle, len(req))
 		for x := range req {
-			klineRet.Candles = append(klineRet.Candles, kline.Candle{
+			candles[x] = kline.Candle{
 				Time:   time.Unix(int64(req[x][0]), 0),
 				Open:   req[x][1],
 				High:   req[x][2],
 				Low:    req[x][3],
 				Close:  req[x][4],
 				Volume: req[x][5],
-			})
+			}
 		}
+		return candles, nil
 	case asset.Futures:
-		return kline.Item{}, common.ErrNotYetImplemented
+		// BTSE offers futures candles, they have not been wired up yet
+		return nil, common.ErrNotYetImplemented
 	default:
-		return kline.Item{}, fmt.Errorf("asset %v not supported", a.String())
+		return nil, fmt.Errorf("%w: asset %v", common.ErrFunctionNotSupported, a)
 	}
-
-	klineRet.SortCandlesByTimestamp(false)
-	return klineRet, nil
 }
 
 func (b *BTSE) seedOrderSizeLimits() error {
@@ -960,6 +1405,7 @@ func (b *BTSE) seedOrderSizeLimits() error {
 			MinOrderSize:     pairs[x].MinOrderSize,
 			MaxOrderSize:     pairs[x].MaxOrderSize,
 			MinSizeIncrement: pairs[x].MinSizeIncrement,
+			ContractSize:     pairs[x].ContractSize,
 		}
 		orderSizeLimitMap.Store(pairs[x].Symbol, tempValues)
 	}
@@ -975,3 +1421,25 @@ func OrderSizeLimits(pair string) (limits OrderSizeLimit, found bool) {
 	val, ok := resp.(OrderSizeLimit)
 	return val, ok
 }
+
+// logError logs an error with consistent key=value context so that wrapper
+// failures can be filtered by exchange, method, pair and asset
+func (b *BTSE) logError(method string, p currency.Pair, a asset.Item, err error) {
+	log.Errorln(log.ExchangeSys, errorLogFields(b.Name, method, p, a, err))
+}
+
+// errorLogFields formats error context as key=value fields, omitting an unset
+// pair or asset
+func errorLogFields(exch, method string, p currency.Pair, a asset.Item, err error) string {
+	fields := []string{
+		"exchange=" + exch,
+		"method=" + method,
+	}
+	if !p.IsEmpty() {
+		fields = append(fields, "pair="+p.String())
+	}
+	if a != "" {
+		fields = append(fields, "asset="+a.String())
+	}
+	return strings.Join(append(fields, fmt.Sprintf("error=%q", err)), " ")
+}
@@ -4,7 +4,6 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
-	"golang.org/x/time/rate"
 )
 
 const (
@@ -18,8 +17,8 @@ const (
 
 // RateLimit implements the request.Limiter interface
 type RateLimit struct {
-	Query  *rate.Limiter
-	Orders *rate.Limiter
+	Query  *request.TrackedLimiter
+	Orders *request.TrackedLimiter
 }
 
 // Limit executes rate limiting functionality for exchange
@@ -33,10 +32,18 @@ func (r *RateLimit) Limit(f request.EndpointLimit) error {
 	return nil
 }
 
+// Status returns the state of the query and order rate limit buckets
+func (r *RateLimit) Status() map[string]request.LimitStatus {
+	return map[string]request.LimitStatus{
+		"query":  r.Query.Status(),
+		"orders": r.Orders.Status(),
+	}
+}
+
 // SetRateLimit returns the rate limit for the exchange
 func SetRateLimit() *RateLimit {
 	return &RateLimit{
-		Orders: request.NewRateLimit(btseRateInterval, btseOrdersLimit),
-		Query:  request.NewRateLimit(btseRateInterval, btseQueryLimit),
+		Orders: request.NewTrackedRateLimit(btseRateInterval, btseOrdersLimit),
+		Query:  request.NewTrackedRateLimit(btseRateInterval, btseQueryLimit),
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -14,8 +15,11 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 )
@@ -23,8 +27,36 @@ import (
 // Coinbene is the overarching type across this package
 type Coinbene struct {
 	exchange.Base
+	spotPairs tradablePairs
+	timeouts  requestTimeouts
+	status    exchange.TradingStatusCache
+
+	// obSequences tracks websocket orderbook versions to detect missed
+	// updates
+	obSequences orderbookSequences
 }
 
+// klineFields are the numeric fields of a kline row in order, following the
+// row time
+var klineFields = []string{"open", "high", "low", "close", "volume", "turnover", "buy volume", "buy turnover"}
+
+// orderbookDepths are the orderbook depths accepted by the spot and swap
+// orderbook endpoints, in ascending order
+var orderbookDepths = []int64{5, 10, 50, 100}
+
+var (
+	errInvalidOrderbookDepth = errors.New("invalid orderbook depth")
+	errPairNotTradable       = errors.New("pair is not tradable")
+	errInvalidKlineData      = errors.New("invalid kline data")
+	errSymbolNotFound        = errors.New("symbol not found in tickers map")
+	errOrdersNotCancelled    = errors.New("orders not cancelled")
+	errNoSwapFeeRate         = errors.New("no swap fee rate found")
+)
+
+// maxBatchCancelOrders is the most order IDs accepted by a single batch
+// cancel request
+const maxBatchCancelOrders = 10
+
 const (
 	coinbeneAPIURL       = "https://openapi-exchange.coinbene.com/api/exchange/"
 	coinbeneSwapAPIURL   = "https://openapi-contract.coinbene.com/api/swap/"
@@ -32,6 +64,23 @@ const (
 	coinbeneSwapAuthPath = "/api/swap/v2"
 	coinbeneAPIVersion   = "v2"
 
+	defaultOrderbookDepth = 100
+	tradablePairsRefresh  = time.Hour
+
+	// coinbeneKlineLimit is the most candles returned by a single spot or
+	// swap kline request
+	coinbeneKlineLimit = 1000
+
+	// rateLimitExceededCode is returned in the response payload when a
+	// request is rejected by the exchange rate limiter
+	rateLimitExceededCode = 429
+	retryBackoffBase      = time.Millisecond * 250
+	retryBackoffMax       = time.Second * 8
+
+	// systemBusyCode is returned when the exchange is temporarily unable to
+	// process a request
+	systemBusyCode = 500
+
 	// Public endpoints
 	coinbeneGetTicker      = "/market/ticker/one"
 	coinbeneGetTickersSpot = "/market/ticker/list"
@@ -105,11 +154,16 @@ func (c *Coinbene) GetOrderbook(symbol string, size int64) (Orderbook, error) {
 		} `json:"data"`
 	}{}
 
+	depth, err := validateOrderbookDepth(size)
+	if err != nil {
+		return Orderbook{}, err
+	}
+
 	params := url.Values{}
 	params.Set("symbol", symbol)
-	params.Set("depth", strconv.FormatInt(size, 10))
+	params.Set("depth", strconv.FormatInt(depth, 10))
 	path := common.EncodeURLValues(c.API.Endpoints.URL+coinbeneAPIVersion+coinbeneGetOrderBook, params)
-	err := c.SendHTTPRequest(path, spotOrderbook, &resp)
+	err = c.SendHTTPRequest(path, spotOrderbook, &resp)
 	if err != nil {
 		return Orderbook{}, err
 	}
@@ -158,12 +212,10 @@ func (c *Coinbene) GetTicker(symbol string) (TickerData, error) {
 	return resp.TickerData, c.SendHTTPRequest(path, spotSpecificTicker, &resp)
 }
 
-// GetTickers gets and all spot tickers supported by the exchange
+// GetTickers gets all spot tickers supported by the exchange in a single
+// request
 func (c *Coinbene) GetTickers() ([]TickerData, error) {
-	resp := struct {
-		TickerData []TickerData `json:"data"`
-	}{}
-
+	var resp spotTickers
 	path := c.API.Endpoints.URL + coinbeneAPIVersion + coinbeneGetTickersSpot
 	return resp.TickerData, c.SendHTTPRequest(path, spotTickerList, &resp)
 }
@@ -188,11 +240,11 @@ func (c *Coinbene) GetTrades(symbol string) (Trades, error) {
 		if err != nil {
 			return nil, err
 		}
-		price, err := strconv.ParseFloat(resp.Data[x][1], 64)
+		price, err := convert.FloatFromField("price", resp.Data[x][1])
 		if err != nil {
 			return nil, err
 		}
-		volume, err := strconv.ParseFloat(resp.Data[x][2], 64)
+		volume, err := convert.FloatFromField("volume", resp.Data[x][2])
 		if err != nil {
 			return nil, err
 		}
@@ -532,7 +584,7 @@ func (c *Coinbene) GetSwapTickers() (SwapTickers, error) {
 		Data SwapTickers `json:"data"`
 	}
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneGetTickers
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneGetTickers
 	err := c.SendHTTPRequest(path, contractTickers, &r)
 	if err != nil {
 		return nil, err
@@ -548,12 +600,32 @@ func (c *Coinbene) GetSwapTicker(symbol string) (SwapTicker, error) {
 	}
 	t, ok := tickers[strings.ToUpper(symbol)]
 	if !ok {
-		return SwapTicker{},
-			fmt.Errorf("symbol %s not found in tickers map", symbol)
+		return SwapTicker{}, fmt.Errorf("%s %w", symbol, errSymbolNotFound)
 	}
 	return t, nil
 }
 
+// GetSwapMarkPrice returns the current mark price for a swap symbol. Coinbene
+// has no dedicated mark or index price endpoint so the mark price is taken
+// from the swap tickers (GET /api/swap/v2/market/tickers). The index price is
+// not published by any Coinbene endpoint, neither in the tickers nor
+// elsewhere, so it cannot be fetched and liquidation distance calculations
+// must source it from another venue
+func (c *Coinbene) GetSwapMarkPrice(symbol string) (SwapMarkPrice, error) {
+	if symbol == "" {
+		return SwapMarkPrice{}, errors.New("a symbol must be specified")
+	}
+	t, err := c.GetSwapTicker(symbol)
+	if err != nil {
+		return SwapMarkPrice{}, err
+	}
+	return SwapMarkPrice{
+		Symbol:    strings.ToUpper(symbol),
+		MarkPrice: t.MarkPrice,
+		Timestamp: t.Timestamp,
+	}, nil
+}
+
 // GetSwapOrderbook returns an orderbook for the specified currency
 func (c *Coinbene) GetSwapOrderbook(symbol string, size int64) (Orderbook, error) {
 	var s Orderbook
@@ -561,11 +633,14 @@ func (c *Coinbene) GetSwapOrderbook(symbol string, size int64) (Orderbook, error
 		return s, fmt.Errorf("a symbol must be specified")
 	}
 
+	depth, err := validateOrderbookDepth(size)
+	if err != nil {
+		return s, err
+	}
+
 	v := url.Values{}
 	v.Set("symbol", symbol)
-	if size != 0 {
-		v.Set("size", strconv.FormatInt(size, 10))
-	}
+	v.Set("size", strconv.FormatInt(depth, 10))
 
 	type resp struct {
 		Data struct {
@@ -577,8 +652,8 @@ func (c *Coinbene) GetSwapOrderbook(symbol string, size int64) (Orderbook, error
 	}
 
 	var r resp
-	path := common.EncodeURLValues(coinbeneSwapAPIURL+coinbeneAPIVersion+coinbeneGetOrderBook, v)
-	err := c.SendHTTPRequest(path, contractOrderbook, &r)
+	path := common.EncodeURLValues(c.API.Endpoints.URLSecondary+coinbeneAPIVersion+coinbeneGetOrderBook, v)
+	err = c.SendHTTPRequest(path, contractOrderbook, &r)
 	if err != nil {
 		return s, err
 	}
@@ -633,7 +708,7 @@ func (c *Coinbene) GetKlines(pair string, start, end time.Time, period string) (
 	}
 	v.Add("period", period)
 
-	path := common.EncodeURLValues(coinbeneAPIURL+coinbeneAPIVersion+coinbeneSpotKlines, v)
+	path := common.EncodeURLValues(c.API.Endpoints.URL+coinbeneAPIVersion+coinbeneSpotKlines, v)
 	if err = c.SendHTTPRequest(path, contractKline, &resp); err != nil {
 		return
 	}
@@ -657,7 +732,7 @@ func (c *Coinbene) GetSwapKlines(symbol string, start, end time.Time, resolution
 	}
 	v.Set("resolution", resolution)
 
-	path := common.EncodeURLValues(coinbeneSwapAPIURL+coinbeneAPIVersion+coinbeneGetKlines, v)
+	path := common.EncodeURLValues(c.API.Endpoints.URLSecondary+coinbeneAPIVersion+coinbeneGetKlines, v)
 	if err = c.SendHTTPRequest(path, contractKline, &resp); err != nil {
 		return
 	}
@@ -676,7 +751,7 @@ func (c *Coinbene) GetSwapTrades(symbol string, limit int) (SwapTrades, error) {
 		Data [][]string `json:"data"`
 	}
 	var r resp
-	path := common.EncodeURLValues(coinbeneSwapAPIURL+coinbeneAPIVersion+coinbeneGetTrades, v)
+	path := common.EncodeURLValues(c.API.Endpoints.URLSecondary+coinbeneAPIVersion+coinbeneGetTrades, v)
 	if err := c.SendHTTPRequest(path, contractTrades, &r); err != nil {
 		return nil, err
 	}
@@ -715,7 +790,7 @@ func (c *Coinbene) GetSwapAccountInfo() (SwapAccountInfo, error) {
 		Data SwapAccountInfo `json:"data"`
 	}
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneAccountInfo
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneAccountInfo
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbeneAccountInfo,
@@ -737,7 +812,7 @@ func (c *Coinbene) GetSwapPositions(symbol string) (SwapPositions, error) {
 		Data SwapPositions `json:"data"`
 	}
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneListSwapPositions
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneListSwapPositions
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbeneListSwapPositions,
@@ -792,7 +867,7 @@ func (c *Coinbene) PlaceSwapOrder(symbol, direction, orderType, marginMode,
 		Data SwapPlaceOrderResponse `json:"data"`
 	}
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbenePlaceOrder
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbenePlaceOrder
 	err := c.SendAuthHTTPRequest(http.MethodPost,
 		path,
 		coinbenePlaceOrder,
@@ -814,7 +889,7 @@ func (c *Coinbene) CancelSwapOrder(orderID string) (string, error) {
 		Data string `json:"data"`
 	}
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneCancelOrder
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneCancelOrder
 	err := c.SendAuthHTTPRequest(http.MethodPost,
 		path,
 		coinbeneCancelOrder,
@@ -842,7 +917,7 @@ func (c *Coinbene) GetSwapOpenOrders(symbol string, pageNum, pageSize int) (Swap
 		Data SwapOrders `json:"data"`
 	}
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneOpenOrders
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneOpenOrders
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbeneOpenOrders,
@@ -869,7 +944,7 @@ func (c *Coinbene) GetSwapOpenOrdersByPage(symbol string, latestOrderID int64) (
 		Data SwapOrders `json:"data"`
 	}
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneOpenOrdersByPage
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneOpenOrdersByPage
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbeneOpenOrdersByPage,
@@ -891,7 +966,7 @@ func (c *Coinbene) GetSwapOrderInfo(orderID string) (SwapOrder, error) {
 		Data SwapOrder `json:"data"`
 	}
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneOrderInfo
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneOrderInfo
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbeneOrderInfo,
@@ -934,7 +1009,7 @@ func (c *Coinbene) GetSwapOrderHistory(beginTime, endTime, symbol string, pageNu
 	}
 
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneClosedOrders
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneClosedOrders
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbeneClosedOrders,
@@ -972,7 +1047,7 @@ func (c *Coinbene) GetSwapOrderHistoryByOrderID(beginTime, endTime, symbol, stat
 	}
 
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneClosedOrdersByPage
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneClosedOrdersByPage
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbeneClosedOrdersByPage,
@@ -988,8 +1063,9 @@ func (c *Coinbene) GetSwapOrderHistoryByOrderID(beginTime, endTime, symbol, stat
 
 // CancelSwapOrders cancels multiple swap order IDs
 func (c *Coinbene) CancelSwapOrders(orderIDs []string) ([]OrderCancellationResponse, error) {
-	if len(orderIDs) > 10 {
-		return nil, errors.New("only 10 orderIDs are allowed at a time")
+	if len(orderIDs) > maxBatchCancelOrders {
+		return nil, fmt.Errorf("only %d orderIDs are allowed at a time",
+			maxBatchCancelOrders)
 	}
 	req := make(map[string]interface{})
 	req["orderIds"] = orderIDs
@@ -998,7 +1074,7 @@ func (c *Coinbene) CancelSwapOrders(orderIDs []string) ([]OrderCancellationRespo
 	}
 
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneBatchCancel
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneBatchCancel
 	err := c.SendAuthHTTPRequest(http.MethodPost,
 		path,
 		coinbeneBatchCancel,
@@ -1012,6 +1088,93 @@ func (c *Coinbene) CancelSwapOrders(orderIDs []string) ([]OrderCancellationRespo
 	return r.Data, nil
 }
 
+// CancelOrdersWithRetry cancels spot or swap orders in batches of
+// maxBatchCancelOrders. Orders which fail to cancel are retried on their own
+// until attempts is reached. The final response for every order ID is
+// returned, along with an error if any remain uncancelled
+func (c *Coinbene) CancelOrdersWithRetry(orderIDs []string, swap bool, attempts int) (map[string]OrderCancellationResponse, error) {
+	cancel := c.CancelSpotOrders
+	if swap {
+		cancel = c.CancelSwapOrders
+	}
+	return cancelOrdersWithRetry(orderIDs, attempts, cancel)
+}
+
+// cancelOrdersWithRetry batches order IDs through cancel, resubmitting only
+// the IDs which failed with a transient error on the previous attempt. Orders
+// rejected outright, such as unknown order IDs, are not resubmitted
+func cancelOrdersWithRetry(orderIDs []string, attempts int, cancel func([]string) ([]OrderCancellationResponse, error)) (map[string]OrderCancellationResponse, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	results := make(map[string]OrderCancellationResponse, len(orderIDs))
+	pending := orderIDs
+	for attempt := 0; attempt < attempts && len(pending) > 0; attempt++ {
+		var failed []string
+		for start := 0; start < len(pending); start += maxBatchCancelOrders {
+			end := start + maxBatchCancelOrders
+			if end > len(pending) {
+				end = len(pending)
+			}
+			batch := pending[start:end]
+			resp, err := cancel(batch)
+			if err != nil {
+				for x := range batch {
+					results[batch[x]] = OrderCancellationResponse{
+						OrderID: batch[x],
+						Message: err.Error(),
+					}
+				}
+				failed = append(failed, batch...)
+				continue
+			}
+			returned := make(map[string]OrderCancellationResponse, len(resp))
+			for x := range resp {
+				returned[resp[x].OrderID] = resp[x]
+			}
+			for x := range batch {
+				r, ok := returned[batch[x]]
+				if !ok {
+					r = OrderCancellationResponse{
+						OrderID: batch[x],
+						Message: "no cancellation response received",
+					}
+				}
+				results[batch[x]] = r
+				if r.Code != 200 && retryableCancelCode(r.Code) {
+					failed = append(failed, batch[x])
+				}
+			}
+		}
+		pending = failed
+	}
+	var notCancelled int
+	for _, r := range results {
+		if r.Code != 200 {
+			notCancelled++
+		}
+	}
+	if notCancelled > 0 {
+		return results, fmt.Errorf("%w: %d of %d orders failed",
+			errOrdersNotCancelled,
+			notCancelled,
+			len(orderIDs))
+	}
+	return results, nil
+}
+
+// retryableCancelCode returns whether a failed cancellation is transient and
+// worth resubmitting. A zero code marks an order with no response, either
+// because the request failed or the order was omitted from the response
+func retryableCancelCode(code int) bool {
+	switch code {
+	case 0, rateLimitExceededCode, systemBusyCode:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetSwapOrderFills returns a list of swap order fills
 func (c *Coinbene) GetSwapOrderFills(symbol, orderID string, lastTradeID int64) (SwapOrderFills, error) {
 	v := url.Values{}
@@ -1029,7 +1192,7 @@ func (c *Coinbene) GetSwapOrderFills(symbol, orderID string, lastTradeID int64)
 	}
 
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbeneOrderFills
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbeneOrderFills
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbeneOrderFills,
@@ -1057,7 +1220,7 @@ func (c *Coinbene) GetSwapFundingRates(pageNum, pageSize int) ([]SwapFundingRate
 	}
 
 	var r resp
-	path := coinbeneSwapAPIURL + coinbeneAPIVersion + coinbenePositionFeeRate
+	path := c.API.Endpoints.URLSecondary + coinbeneAPIVersion + coinbenePositionFeeRate
 	err := c.SendAuthHTTPRequest(http.MethodGet,
 		path,
 		coinbenePositionFeeRate,
@@ -1079,7 +1242,9 @@ func (c *Coinbene) SendHTTPRequest(path string, f request.EndpointLimit, result
 		Message string `json:"message"`
 	}{}
 
-	if err := c.SendPayload(context.Background(), &request.Item{
+	ctx, cancel := c.requestContext(context.Background(), f)
+	defer cancel()
+	if err := c.SendPayload(ctx, &request.Item{
 		Method:        http.MethodGet,
 		Path:          path,
 		Result:        &resp,
@@ -1162,6 +1327,8 @@ func (c *Coinbene) SendAuthHTTPRequest(method, path, epPath string, isSwap bool,
 	// Expiry of timestamp doesn't appear to be documented, so making a reasonable assumption
 	ctx, cancel := context.WithDeadline(context.Background(), now.Add(15*time.Second))
 	defer cancel()
+	ctx, cancelCategory := c.requestContext(ctx, f)
+	defer cancelCategory()
 	if err := c.SendPayload(ctx, &request.Item{
 		Method:        method,
 		Path:          path,
@@ -1183,3 +1350,216 @@ func (c *Coinbene) SendAuthHTTPRequest(method, path, epPath string, isSwap bool,
 	}
 	return json.Unmarshal(resp, result)
 }
+
+// validateOrderbookDepth returns the orderbook depth to request, defaulting
+// when zero and rounding up to the nearest depth supported by the exchange
+func validateOrderbookDepth(size int64) (int64, error) {
+	if size == 0 {
+		return defaultOrderbookDepth, nil
+	}
+	if size < 0 {
+		return 0, fmt.Errorf("%w %d, must be positive", errInvalidOrderbookDepth, size)
+	}
+	for x := range orderbookDepths {
+		if size <= orderbookDepths[x] {
+			return orderbookDepths[x], nil
+		}
+	}
+	return 0, fmt.Errorf("%w %d, max depth is %d",
+		errInvalidOrderbookDepth,
+		size,
+		orderbookDepths[len(orderbookDepths)-1])
+}
+
+// checkTradablePair returns an error if the spot symbol is not in the cached
+// list of tradable pairs, refreshing the cache when it has gone stale
+func (c *Coinbene) checkTradablePair(symbol string) error {
+	c.spotPairs.m.RLock()
+	stale := time.Since(c.spotPairs.updated) > tradablePairsRefresh
+	c.spotPairs.m.RUnlock()
+	if stale {
+		pairs, err := c.GetAllPairs()
+		if err != nil {
+			return err
+		}
+		c.setTradablePairs(pairs)
+	}
+
+	c.spotPairs.m.RLock()
+	defer c.spotPairs.m.RUnlock()
+	if _, ok := c.spotPairs.pairs[symbol]; !ok {
+		return fmt.Errorf("%s %w: %s", c.Name, errPairNotTradable, symbol)
+	}
+	return nil
+}
+
+// setTradablePairs replaces the cached spot pairs
+func (c *Coinbene) setTradablePairs(pairs []PairData) {
+	m := make(map[string]PairData, len(pairs))
+	for x := range pairs {
+		m[pairs[x].Symbol] = pairs[x]
+	}
+	c.spotPairs.m.Lock()
+	c.spotPairs.pairs = m
+	c.spotPairs.updated = time.Now()
+	c.spotPairs.m.Unlock()
+}
+
+// SetRequestTimeout sets the timeout for a category of REST requests, a zero
+// timeout reverts to the HTTP client timeout
+func (c *Coinbene) SetRequestTimeout(category RequestCategory, timeout time.Duration) {
+	c.timeouts.m.Lock()
+	defer c.timeouts.m.Unlock()
+	if c.timeouts.timeouts == nil {
+		c.timeouts.timeouts = make(map[RequestCategory]time.Duration)
+	}
+	c.timeouts.timeouts[category] = timeout
+}
+
+// requestContext applies the timeout set for the endpoint's request category
+// to the parent context
+func (c *Coinbene) requestContext(parent context.Context, f request.EndpointLimit) (context.Context, context.CancelFunc) {
+	c.timeouts.m.RLock()
+	timeout := c.timeouts.timeouts[requestCategory(f)]
+	c.timeouts.m.RUnlock()
+	if timeout <= 0 {
+		return parent, func() {}
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// requestCategory returns the request category for an endpoint
+func requestCategory(f request.EndpointLimit) RequestCategory {
+	switch f {
+	case contractPlaceOrder, contractCancelOrder, contractGetOpenOrders,
+		contractOpenOrdersByPage, contractGetOrderInfo,
+		contractCancelMultipleOrders, spotPlaceOrder, spotBatchOrder,
+		spotQueryOpenOrders, spotQuerySpecficOrder, spotCancelOrder,
+		spotCancelOrdersBatch:
+		return OrderRequests
+	case contractKline, contractGetClosedOrders, contractGetClosedOrdersbyPage,
+		contractGetOrderFills, contractGetFundingRates, spotKline,
+		spotQueryClosedOrders, spotQueryTradeFills:
+		return HistoryRequests
+	default:
+		return MarketDataRequests
+	}
+}
+
+// GetFee returns an estimate of fee based on type of transaction
+func (c *Coinbene) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	var fee float64
+	switch feeBuilder.FeeType {
+	case exchange.CryptocurrencyTradeFee:
+		rate, err := c.calculateTradingFee(feeBuilder)
+		if err != nil {
+			return 0, err
+		}
+		fee = rate * feeBuilder.PurchasePrice * feeBuilder.Amount
+	case exchange.CryptocurrencyWithdrawalFee:
+		// Withdrawal fees are not exposed by the API
+		return 0, common.ErrFunctionNotSupported
+	case exchange.CyptocurrencyDepositFee:
+		// Coinbene does not charge for crypto deposits
+	case exchange.OfflineTradeFee:
+		fee = getOfflineTradeFee(feeBuilder.PurchasePrice, feeBuilder.Amount)
+	}
+	return fee, nil
+}
+
+// GetTradeFeePercent returns the maker or taker trading fee rate for a spot
+// pair as a fraction of the traded value, e.g. 0.001 for 0.1%
+func (c *Coinbene) GetTradeFeePercent(pair currency.Pair, isMaker bool) (float64, error) {
+	return c.calculateTradingFee(&exchange.FeeBuilder{
+		FeeType: exchange.CryptocurrencyTradeFee,
+		Pair:    pair,
+		IsMaker: isMaker,
+	})
+}
+
+// calculateTradingFee returns the maker or taker fee rate for a spot pair,
+// pairs only listed as perpetual swaps use the swap position fee rate as the
+// fee builder carries no asset type
+func (c *Coinbene) calculateTradingFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	if c.isSwapOnlyPair(feeBuilder.Pair) {
+		return c.GetSwapFeeRate(feeBuilder.Pair)
+	}
+	fpair, err := c.FormatExchangeCurrency(feeBuilder.Pair, asset.Spot)
+	if err != nil {
+		return 0, err
+	}
+
+	pairInfo, err := c.GetPairInfo(fpair.String())
+	if err != nil {
+		return 0, err
+	}
+
+	if feeBuilder.IsMaker {
+		return pairInfo.MakerFeeRate, nil
+	}
+	return pairInfo.TakerFeeRate, nil
+}
+
+// getOfflineTradeFee calculates the worst case-scenario trading fee using the
+// standard spot taker rate
+func getOfflineTradeFee(price, amount float64) float64 {
+	return 0.001 * price * amount
+}
+
+// isSwapOnlyPair returns whether a pair is available as a perpetual swap but
+// not as a spot pair
+func (c *Coinbene) isSwapOnlyPair(p currency.Pair) bool {
+	swap, err := c.GetAvailablePairs(asset.PerpetualSwap)
+	if err != nil || !swap.Contains(p, true) {
+		return false
+	}
+	spot, err := c.GetAvailablePairs(asset.Spot)
+	return err != nil || !spot.Contains(p, true)
+}
+
+// GetSwapFeeRate returns the most recent position fee rate charged on a
+// perpetual swap contract
+func (c *Coinbene) GetSwapFeeRate(p currency.Pair) (float64, error) {
+	fpair, err := c.FormatExchangeCurrency(p, asset.PerpetualSwap)
+	if err != nil {
+		return 0, err
+	}
+	rates, err := c.GetSwapFundingRates(1, 100)
+	if err != nil {
+		return 0, err
+	}
+	for x := range rates {
+		if rates[x].Symbol == fpair.String() {
+			return rates[x].FeeRate, nil
+		}
+	}
+	return 0, fmt.Errorf("%s %w", fpair, errNoSwapFeeRate)
+}
+
+// retryPolicy retries requests rejected by the exchange rate limiter, which is
+// signalled either by the HTTP status or by the error code in the payload. The
+// requester waits for any Retry-After duration before retrying and falls back
+// to the configured backoff when it is absent
+func retryPolicy(resp *http.Response, err error) (bool, error) {
+	retry, err := request.DefaultRetryPolicy(resp, err)
+	if retry || err != nil || resp == nil || resp.Body == nil {
+		return retry, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var errCap struct {
+		Code int `json:"code"`
+	}
+	if json.Unmarshal(body, &errCap) == nil && errCap.Code == rateLimitExceededCode {
+		return true, nil
+	}
+	return false, nil
+}
@@ -1,17 +1,34 @@
 package coinbene
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
 
 // Please supply your own keys here for due diligence testing
@@ -84,6 +101,40 @@ func TestGetTicker(t *testing.T) {
 	}
 }
 
+func TestGetTickers(t *testing.T) {
+	t.Parallel()
+	_, err := c.GetTickers()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSpotTickersUnmarshal(t *testing.T) {
+	t.Parallel()
+	pressXToJSON := []byte(`{"code":200,"data":[{"symbol":"BTC/USDT","latestPrice":"11400.23","bestBid":"11400.1","bestAsk":"11400.5","high24h":"11500","low24h":"11200.2","volume24h":"1250.345","chg24h":"0.0123","chg0h":"0.0045"},{"symbol":"ETH/USDT","latestPrice":"365.12","bestBid":"365.1","bestAsk":"365.2","high24h":"370","low24h":"360","volume24h":"9876.5","chg24h":"-0.01","chg0h":"0.002"}]}`)
+	var resp spotTickers
+	err := json.Unmarshal(pressXToJSON, &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.TickerData) != 2 {
+		t.Fatalf("expected 2 tickers, received %v", len(resp.TickerData))
+	}
+	if resp.TickerData[0].Symbol != spotTestPair ||
+		resp.TickerData[0].LatestPrice != 11400.23 ||
+		resp.TickerData[0].BestBid != 11400.1 ||
+		resp.TickerData[0].BestAsk != 11400.5 ||
+		resp.TickerData[0].DailyHigh != 11500 ||
+		resp.TickerData[0].DailyLow != 11200.2 ||
+		resp.TickerData[0].DailyVolume != 1250.345 {
+		t.Errorf("unexpected ticker data %+v", resp.TickerData[0])
+	}
+	if resp.TickerData[1].Symbol != "ETH/USDT" ||
+		resp.TickerData[1].LatestPrice != 365.12 {
+		t.Errorf("unexpected ticker data %+v", resp.TickerData[1])
+	}
+}
+
 func TestGetTrades(t *testing.T) {
 	t.Parallel()
 	_, err := c.GetTrades(spotTestPair)
@@ -236,6 +287,59 @@ func TestUpdateTicker(t *testing.T) {
 	}
 }
 
+func TestProcessSpotTicker(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPairWithDelimiter("LTC", "BTC", "/")
+	err := c.processSpotTicker(&TickerData{
+		Symbol:      "LTC/BTC",
+		LatestPrice: 0.0045,
+		BestBid:     0.0044,
+		BestAsk:     0.0046,
+		DailyHigh:   0.005,
+		DailyLow:    0.004,
+		DailyVolume: 1337,
+	}, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tick, err := ticker.GetTicker(c.Name, p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tick.Last != 0.0045 || tick.Bid != 0.0044 || tick.Ask != 0.0046 ||
+		tick.High != 0.005 || tick.Low != 0.004 || tick.Volume != 1337 {
+		t.Errorf("unexpected spot ticker values %+v", tick)
+	}
+}
+
+func TestProcessSwapTicker(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPairWithDelimiter("LTC", "USDT", "/")
+	tm := time.Date(2020, 10, 16, 0, 0, 0, 0, time.UTC)
+	err := c.processSwapTicker(&SwapTicker{
+		LastPrice:    50.5,
+		MarkPrice:    50.4,
+		BestBidPrice: 50.3,
+		BestAskPrice: 50.6,
+		High24Hour:   52,
+		Low24Hour:    49,
+		Volume24Hour: 8008,
+		Timestamp:    tm,
+	}, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tick, err := ticker.GetTicker(c.Name, p, asset.PerpetualSwap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tick.Last != 50.5 || tick.Bid != 50.3 || tick.Ask != 50.6 ||
+		tick.High != 52 || tick.Low != 49 || tick.Volume != 8008 ||
+		!tick.LastUpdated.Equal(tm) {
+		t.Errorf("unexpected swap ticker values %+v", tick)
+	}
+}
+
 func TestGetAccountInfo(t *testing.T) {
 	t.Parallel()
 	if !areTestAPIKeysSet() {
@@ -260,6 +364,316 @@ func TestUpdateOrderbook(t *testing.T) {
 	}
 }
 
+func TestValidateOrderbookDepth(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		size     int64
+		expected int64
+		err      error
+	}{
+		{size: 0, expected: defaultOrderbookDepth},
+		{size: 10, expected: 10},
+		{size: 20, expected: 50},
+		{size: 100, expected: 100},
+		{size: 101, err: errInvalidOrderbookDepth},
+		{size: -1, err: errInvalidOrderbookDepth},
+	}
+	for x := range tests {
+		depth, err := validateOrderbookDepth(tests[x].size)
+		if !errors.Is(err, tests[x].err) {
+			t.Errorf("size %d: expected %v, received %v", tests[x].size, tests[x].err, err)
+		}
+		if depth != tests[x].expected {
+			t.Errorf("size %d: expected %v, received %v", tests[x].size, tests[x].expected, depth)
+		}
+	}
+}
+
+func TestOrderbookDepthRejectedLocally(t *testing.T) {
+	t.Parallel()
+	_, err := c.GetOrderbook(spotTestPair, 1000)
+	if !errors.Is(err, errInvalidOrderbookDepth) {
+		t.Errorf("expected %v, received %v", errInvalidOrderbookDepth, err)
+	}
+	_, err = c.GetSwapOrderbook(swapTestPair, 1000)
+	if !errors.Is(err, errInvalidOrderbookDepth) {
+		t.Errorf("expected %v, received %v", errInvalidOrderbookDepth, err)
+	}
+}
+
+func TestRequestCategoryTimeout(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond * 200)
+		_, err := w.Write([]byte(`{"code":200,"data":[]}`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.Name = "Coinbene"
+	cb.Requester = request.New(cb.Name,
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout),
+		request.WithRetryPolicy(func(_ *http.Response, err error) (bool, error) {
+			return false, err
+		}))
+
+	var resp struct {
+		Data []PairData `json:"data"`
+	}
+	cb.SetRequestTimeout(MarketDataRequests, time.Millisecond*20)
+	err := cb.SendHTTPRequest(server.URL, spotPairs, &resp)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v, received %v", context.DeadlineExceeded, err)
+	}
+
+	cb.SetRequestTimeout(MarketDataRequests, time.Second*5)
+	err = cb.SendHTTPRequest(server.URL, spotPairs, &resp)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if requestCategory(spotPlaceOrder) != OrderRequests ||
+		requestCategory(contractKline) != HistoryRequests ||
+		requestCategory(spotTickerList) != MarketDataRequests {
+		t.Error("unexpected request category mapping")
+	}
+}
+
+func TestGetFee(t *testing.T) {
+	t.Parallel()
+	feeBuilder := &exchange.FeeBuilder{
+		FeeType:       exchange.OfflineTradeFee,
+		Pair:          currency.NewPair(currency.BTC, currency.USDT),
+		PurchasePrice: 10000,
+		Amount:        1,
+	}
+	fee, err := c.GetFee(feeBuilder)
+	if err != nil {
+		t.Error(err)
+	}
+	if fee != 10 {
+		t.Errorf("expected %v, received %v", 10, fee)
+	}
+
+	feeBuilder.FeeType = exchange.CryptocurrencyWithdrawalFee
+	_, err = c.GetFee(feeBuilder)
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v, received %v", common.ErrFunctionNotSupported, err)
+	}
+
+	feeBuilder.FeeType = exchange.CyptocurrencyDepositFee
+	fee, err = c.GetFee(feeBuilder)
+	if err != nil {
+		t.Error(err)
+	}
+	if fee != 0 {
+		t.Errorf("expected %v, received %v", 0, fee)
+	}
+}
+
+func TestGetFeeByType(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch {
+		case strings.HasSuffix(r.URL.Path, coinbenePairInfo):
+			_, err = w.Write([]byte(`{"code":200,"data":{"symbol":"BTC/USDT","takerFeeRate":"0.002","makerFeeRate":"0.0008"}}`))
+		case strings.HasSuffix(r.URL.Path, coinbenePositionFeeRate):
+			if r.Header.Get("ACCESS-KEY") == "" {
+				t.Error("expected an authenticated swap fee request")
+			}
+			_, err = w.Write([]byte(`{"code":200,"data":[{"symbol":"ETHUSDT","feeRate":"0.0001"},{"symbol":"LTCUSDT","feeRate":"0.0003"}]}`))
+		default:
+			t.Errorf("unexpected path %v", r.URL.Path)
+		}
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.SetDefaults()
+	cb.API.Endpoints.URL = server.URL + "/"
+	cb.API.Endpoints.URLSecondary = server.URL + "/"
+	cb.Requester = request.New(cb.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	spot := currency.NewPairWithDelimiter("BTC", "USDT", "/")
+	swap := currency.NewPairWithDelimiter("LTC", "USDT", "/")
+	cb.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{spot}, false)
+	cb.CurrencyPairs.StorePairs(asset.PerpetualSwap, currency.Pairs{spot, swap}, false)
+
+	// Spot rates come from the public pair info endpoint without credentials
+	feeBuilder := &exchange.FeeBuilder{
+		FeeType:       exchange.CryptocurrencyTradeFee,
+		Pair:          spot,
+		PurchasePrice: 10000,
+		Amount:        1,
+	}
+	fee, err := cb.GetFeeByType(feeBuilder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee != 20 {
+		t.Errorf("expected %v, received %v", 20, fee)
+	}
+	if feeBuilder.FeeType != exchange.CryptocurrencyTradeFee {
+		t.Errorf("expected %v, received %v", exchange.CryptocurrencyTradeFee, feeBuilder.FeeType)
+	}
+
+	feeBuilder.Pair = swap
+	_, err = cb.GetFeeByType(feeBuilder)
+	if err == nil {
+		t.Error("expected an error requesting swap fees without credentials")
+	}
+
+	cb.API.Credentials.Key = "key"
+	cb.API.Credentials.Secret = "secret"
+	fee, err = cb.GetFeeByType(feeBuilder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(fee-3) > 1e-9 {
+		t.Errorf("expected %v, received %v", 3, fee)
+	}
+
+	cb.CurrencyPairs.StorePairs(asset.PerpetualSwap,
+		currency.Pairs{spot, swap, currency.NewPairWithDelimiter("XRP", "USDT", "/")},
+		false)
+	_, err = cb.GetSwapFeeRate(currency.NewPairWithDelimiter("XRP", "USDT", "/"))
+	if !errors.Is(err, errNoSwapFeeRate) {
+		t.Errorf("expected %v, received %v", errNoSwapFeeRate, err)
+	}
+}
+
+func TestCoinbeneStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		status   string
+		expected order.Status
+	}{
+		{"new", order.New},
+		{"partiallyFilled", order.PartiallyFilled},
+		{"filled", order.Filled},
+		{"partiallyCanceled", order.PartiallyCancelled},
+		{"cancelled", order.Cancelled},
+		{"canceled", order.Cancelled},
+		{"rejected", order.Rejected},
+		{"", order.UnknownStatus},
+		{"bad", order.UnknownStatus},
+	}
+	for x := range tests {
+		if s := coinbeneStatus(tests[x].status); s != tests[x].expected {
+			t.Errorf("%s: expected %v, received %v", tests[x].status, tests[x].expected, s)
+		}
+	}
+}
+
+func TestSetDefaults(t *testing.T) {
+	t.Parallel()
+	var cb Coinbene
+	cb.SetDefaults()
+
+	assets := cb.GetAssetTypes()
+	if len(assets) != 2 ||
+		!cb.SupportsAsset(asset.Spot) ||
+		!cb.SupportsAsset(asset.PerpetualSwap) {
+		t.Errorf("expected spot and perpetual swap assets, received %v", assets)
+	}
+	if !cb.Features.Supports.REST || !cb.Features.Supports.Websocket {
+		t.Error("expected REST and websocket support")
+	}
+	if !cb.Features.Supports.RESTCapabilities.KlineFetching ||
+		!cb.Features.Supports.WebsocketCapabilities.KlineFetching {
+		t.Error("expected kline fetching support")
+	}
+	if !cb.Features.Enabled.Kline.Intervals[kline.OneMin.Word()] ||
+		!cb.Features.Enabled.Kline.Intervals[kline.OneWeek.Word()] {
+		t.Error("expected kline intervals to be enabled")
+	}
+}
+
+func TestCheckTradablePair(t *testing.T) {
+	t.Parallel()
+	var cb Coinbene
+	cb.Name = "Coinbene"
+	cb.setTradablePairs([]PairData{{Symbol: "BTC/USDT"}})
+
+	err := cb.checkTradablePair("BTC/USDT")
+	if err != nil {
+		t.Error(err)
+	}
+	// API endpoints are unset so this would error differently if a request
+	// were made
+	err = cb.checkTradablePair("FAKE/USDT")
+	if !errors.Is(err, errPairNotTradable) {
+		t.Errorf("expected %v, received %v", errPairNotTradable, err)
+	}
+}
+
+func TestSpotTradablePairs(t *testing.T) {
+	t.Parallel()
+	pairs := spotTradablePairs([]PairData{{Symbol: "BTC/USDT"}, {Symbol: "LTC/BTC"}})
+	if len(pairs) != 2 || pairs[0] != "BTC/USDT" || pairs[1] != "LTC/BTC" {
+		t.Errorf("unexpected spot pairs %v", pairs)
+	}
+}
+
+func TestSwapTradablePairs(t *testing.T) {
+	t.Parallel()
+	pairs, err := swapTradablePairs(SwapTickers{
+		"LTCUSDT": {LastPrice: 50},
+		"BTCUSDT": {LastPrice: 10000, MarkPrice: 10000},
+		"XRPUSDT": {},
+	}, "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 2 || pairs[0] != "BTC-USDT" || pairs[1] != "LTC-USDT" {
+		t.Errorf("unexpected swap pairs %v", pairs)
+	}
+
+	_, err = swapTradablePairs(SwapTickers{"BTCUSD": {LastPrice: 1}}, "-")
+	if err == nil {
+		t.Error("expected an error for a non USDT swap contract")
+	}
+}
+
+func TestConvertOrderbook(t *testing.T) {
+	t.Parallel()
+	ob := Orderbook{
+		Bids: []OrderbookItem{{Price: 100, Amount: 1, Count: 2}},
+		Asks: []OrderbookItem{{Price: 101, Amount: 3, Count: 4}},
+	}
+
+	spotPair := currency.NewPairWithDelimiter("BTC", "USDT", "/")
+	spot := c.convertOrderbook(&ob, spotPair, asset.Spot)
+	if spot.ExchangeName != c.Name || spot.AssetType != asset.Spot ||
+		!spot.Pair.Equal(spotPair) {
+		t.Errorf("unexpected spot orderbook details %+v", spot)
+	}
+	if len(spot.Bids) != 1 || spot.Bids[0].Price != 100 ||
+		spot.Bids[0].Amount != 1 || spot.Bids[0].OrderCount != 0 {
+		t.Errorf("unexpected spot bids %+v", spot.Bids)
+	}
+	if len(spot.Asks) != 1 || spot.Asks[0].Price != 101 ||
+		spot.Asks[0].Amount != 3 || spot.Asks[0].OrderCount != 0 {
+		t.Errorf("unexpected spot asks %+v", spot.Asks)
+	}
+
+	swapPair := currency.NewPair(currency.BTC, currency.USDT)
+	swap := c.convertOrderbook(&ob, swapPair, asset.PerpetualSwap)
+	if swap.AssetType != asset.PerpetualSwap {
+		t.Errorf("expected %v, received %v", asset.PerpetualSwap, swap.AssetType)
+	}
+	if swap.Bids[0].OrderCount != 2 || swap.Asks[0].OrderCount != 4 {
+		t.Errorf("expected swap order counts to be carried, received bids %+v asks %+v",
+			swap.Bids, swap.Asks)
+	}
+}
+
 func TestGetSwapTickers(t *testing.T) {
 	t.Parallel()
 	_, err := c.GetSwapTickers()
@@ -492,8 +906,18 @@ func TestWsLogin(t *testing.T) {
 
 	pressXToJSON = []byte(`{"event":"login","success":false}`)
 	err = c.wsHandleData(pressXToJSON)
-	if err == nil {
-		t.Error("Expected error")
+	if !errors.Is(err, errWsLoginRejected) {
+		t.Errorf("expected %v, received %v", errWsLoginRejected, err)
+	}
+}
+
+func TestWsLoginSignature(t *testing.T) {
+	var cb Coinbene
+	cb.API.Credentials.Secret = "secret"
+	sig := cb.wsLoginSignature("2020-10-16T10:00:00Z")
+	expected := "82912a5f14f886e39c08c6990b1856d2e4669cdbce191e5c2948fc91c4d5b418"
+	if sig != expected {
+		t.Errorf("expected %s, received %s", expected, sig)
 	}
 }
 
@@ -730,6 +1154,72 @@ func TestGetHistoricCandlesExtended(t *testing.T) {
 	}
 }
 
+func TestGetHistoricCandlesExtendedPagination(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		start, err := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+		if err != nil {
+			t.Error(err)
+		}
+		end, err := strconv.ParseInt(r.URL.Query().Get("end"), 10, 64)
+		if err != nil {
+			t.Error(err)
+		}
+		// Ranges are inclusive of the end time, duplicates at page
+		// boundaries are removed when combined
+		data := [][]string{}
+		for ts := start; ts <= end; ts += 60 {
+			data = append(data, []string{
+				time.Unix(ts, 0).UTC().Format(time.RFC3339),
+				"1", "2", "0.5", "1.5", "10",
+			})
+		}
+		err = json.NewEncoder(w).Encode(map[string]interface{}{"code": 200, "data": data})
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.SetDefaults()
+	cb.API.Endpoints.URL = server.URL + "/"
+	cb.Requester = request.New(cb.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	p := currency.NewPairWithDelimiter("BTC", "USDT", "/")
+	cb.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{p}, false)
+	cb.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{p}, true)
+	if err := cb.CurrencyPairs.SetAssetEnabled(asset.Spot, true); err != nil {
+		t.Fatal(err)
+	}
+
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-2500 * time.Minute)
+	item, err := cb.GetHistoricCandlesExtended(p, asset.Spot, start, end, kline.OneMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := atomic.LoadInt32(&requests); r != 3 {
+		t.Errorf("expected %v requests for %v candle limit, received %v", 3, coinbeneKlineLimit, r)
+	}
+	if len(item.Candles) != 2501 {
+		t.Errorf("expected %v, received %v", 2501, len(item.Candles))
+	}
+
+	_, err = cb.GetHistoricCandles(p, asset.Spot, start, end, kline.OneMin)
+	if err == nil || err.Error() != kline.ErrRequestExceedsExchangeLimits {
+		t.Errorf("expected %v, received %v", kline.ErrRequestExceedsExchangeLimits, err)
+	}
+	for x := 1; x < len(item.Candles); x++ {
+		if !item.Candles[x].Time.After(item.Candles[x-1].Time) {
+			t.Fatalf("expected sorted unique candles, received %v after %v",
+				item.Candles[x].Time,
+				item.Candles[x-1].Time)
+		}
+	}
+}
+
 func Test_FormatExchangeKlineInterval(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -780,3 +1270,590 @@ func Test_FormatExchangeKlineInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimitStatus(t *testing.T) {
+	t.Parallel()
+	r := SetRateLimit()
+	before := r.Status()["spotPairs"]
+	if before.Remaining != 1 {
+		t.Errorf("expected %v, received %v", 1, before.Remaining)
+	}
+	err := r.Limit(spotPairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := r.Status()["spotPairs"]
+	if after.Remaining >= before.Remaining {
+		t.Errorf("expected remaining tokens to decrease from %v, received %v",
+			before.Remaining,
+			after.Remaining)
+	}
+	if untouched := r.Status()["spotOrderbook"]; untouched.Remaining != 1 {
+		t.Errorf("expected %v, received %v", 1, untouched.Remaining)
+	}
+
+	var cb Coinbene
+	cb.SetDefaults()
+	reporter, ok := exchange.IBotExchange(&cb).(exchange.RateLimitStatusReporter)
+	if !ok {
+		t.Fatal("expected Coinbene to report rate limit status")
+	}
+	status, err := reporter.GetRateLimitStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = status["spotPairs"]; !ok {
+		t.Error("expected spotPairs bucket status")
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := `{"code":200,"data":[]}`
+		switch atomic.AddInt32(&hits, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			resp = `{"code":429,"message":"too many requests"}`
+		case 2:
+			resp = `{"code":429,"message":"too many requests"}`
+		}
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.Name = "Coinbene"
+	cb.Requester = request.New(cb.Name,
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout),
+		request.WithRetryPolicy(retryPolicy),
+		request.WithBackoff(request.ExponentialBackoff(time.Millisecond*10, time.Millisecond*50)))
+
+	var resp struct {
+		Data []PairData `json:"data"`
+	}
+	start := time.Now()
+	err := cb.SendHTTPRequest(server.URL, spotPairs, &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected retry to wait for the retry after duration, waited %v", elapsed)
+	}
+	if hits != 3 {
+		t.Errorf("expected %v requests, received %v", 3, hits)
+	}
+}
+
+func TestParseKlines(t *testing.T) {
+	t.Parallel()
+	var resp CandleResponse
+	err := json.Unmarshal([]byte(`{"code":200,"data":[
+		["2020-10-17T10:00:00.000Z","100","110","90","105","2","210","1.5","157.5"],
+		["2020-10-17T10:01:00.000Z","105","106","104","104","3"],
+		["invalid","1","1","1","1","1"]
+	]}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	klines, err := parseKlines(resp.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 2 {
+		t.Fatalf("expected %v, received %v", 2, len(klines))
+	}
+	swap := klines[0]
+	if swap.Open != 100 || swap.High != 110 || swap.Low != 90 || swap.Close != 105 ||
+		swap.Volume != 2 || swap.Turnover != 210 || swap.BuyVolume != 1.5 || swap.BuyTurnover != 157.5 {
+		t.Errorf("unexpected swap kline %+v", swap)
+	}
+	if klines[1].Volume != 3 || klines[1].Turnover != 0 {
+		t.Errorf("unexpected spot kline %+v", klines[1])
+	}
+
+	_, err = parseKlines([][]interface{}{{"2020-10-17T10:00:00.000Z", "1"}})
+	if !errors.Is(err, errInvalidKlineData) {
+		t.Errorf("expected %v, received %v", errInvalidKlineData, err)
+	}
+	_, err = parseKlines([][]interface{}{{"2020-10-17T10:00:00.000Z", "1", 2.0, "1", "1", "1"}})
+	if !errors.Is(err, errInvalidKlineData) {
+		t.Errorf("expected %v, received %v", errInvalidKlineData, err)
+	}
+}
+
+func TestSwapKlinesCandles(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2020, 10, 17, 10, 0, 0, 0, time.UTC)
+	candles := SwapKlines{{
+		Time:        ts,
+		Open:        100,
+		High:        110,
+		Low:         90,
+		Close:       105,
+		Volume:      2,
+		Turnover:    210,
+		BuyVolume:   1.5,
+		BuyTurnover: 157.5,
+	}}.Candles()
+	if len(candles) != 1 {
+		t.Fatalf("expected %v, received %v", 1, len(candles))
+	}
+	if !candles[0].Time.Equal(ts) || candles[0].Volume != 2 ||
+		candles[0].QuoteVolume != 210 || candles[0].TakerBuyVolume != 1.5 {
+		t.Errorf("unexpected candle %+v", candles[0])
+	}
+}
+
+func TestSwapTickerOpenInterest(t *testing.T) {
+	t.Parallel()
+	var tickers SwapTickers
+	err := json.Unmarshal([]byte(`{"BTCUSDT":{"lastPrice":"11400.5","markPrice":"11401.2","bestAskPrice":"11401","bestBidPrice":"11400","high24h":"11500","low24h":"11200","volume24h":"1020304","bestAskVolume":"12","bestBidVolume":"30","turnover":"116321","openInterest":"523411","timeStamp":"2020-10-17T10:00:00.000Z"}}`), &tickers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tickers["BTCUSDT"].OpenInterest != 523411 {
+		t.Errorf("expected %v, received %v", 523411, tickers["BTCUSDT"].OpenInterest)
+	}
+}
+
+func TestGetSwapMarkPrice(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+coinbeneAPIVersion+coinbeneGetTickers {
+			t.Errorf("unexpected path %v", r.URL.Path)
+		}
+		_, err := w.Write([]byte(`{"code":200,"data":{"BTCUSDT":{"lastPrice":"11400.5","markPrice":"11401.2","timeStamp":"2020-10-17T10:00:00.000Z"}}}`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.SetDefaults()
+	cb.API.Endpoints.URLSecondary = server.URL + "/"
+	cb.Requester = request.New(cb.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	mark, err := cb.GetSwapMarkPrice(swapTestPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mark.Symbol != swapTestPair || mark.MarkPrice != 11401.2 {
+		t.Errorf("unexpected mark price %+v", mark)
+	}
+	expected := time.Date(2020, 10, 17, 10, 0, 0, 0, time.UTC)
+	if !mark.Timestamp.Equal(expected) {
+		t.Errorf("expected %v, received %v", expected, mark.Timestamp)
+	}
+
+	_, err = cb.GetSwapMarkPrice("ETHUSDT")
+	if !errors.Is(err, errSymbolNotFound) {
+		t.Errorf("expected %v, received %v", errSymbolNotFound, err)
+	}
+}
+
+func TestGetTradeFeePercent(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTC/USDT" {
+			t.Errorf("unexpected symbol %v", r.URL.Query().Get("symbol"))
+		}
+		_, err := w.Write([]byte(`{"code":200,"data":{"symbol":"BTC/USDT","baseAsset":"BTC","quoteAsset":"USDT","pricePrecision":"2","amountPrecision":"4","takerFeeRate":"0.002","makerFeeRate":"0.0008","minAmount":"0.0001","site":"MAIN","priceFluctuation":"0.05"}}`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.SetDefaults()
+	cb.API.Endpoints.URL = server.URL + "/"
+	cb.Requester = request.New(cb.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	maker, err := cb.GetTradeFeePercent(p, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maker != 0.0008 {
+		t.Errorf("expected %v, received %v", 0.0008, maker)
+	}
+	taker, err := cb.GetTradeFeePercent(p, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taker != 0.002 {
+		t.Errorf("expected %v, received %v", 0.002, taker)
+	}
+}
+
+func TestInvalidNumericField(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"code":200,"data":[["BTC/USDT","11400.5","0.2","buy","2020-10-17T10:00:00Z"],["BTC/USDT","11400.x","0.1","sell","2020-10-17T10:00:01Z"]]}`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.SetDefaults()
+	cb.API.Endpoints.URL = server.URL + "/"
+	cb.Requester = request.New(cb.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	_, err := cb.GetTrades("BTC/USDT")
+	if !errors.Is(err, convert.ErrInvalidNumericField) {
+		t.Fatalf("expected %v, received %v", convert.ErrInvalidNumericField, err)
+	}
+	if !strings.Contains(err.Error(), `price value "11400.x"`) {
+		t.Errorf("unexpected error message %v", err)
+	}
+
+	_, err = parseKlines([][]interface{}{{"2020-10-17T10:00:00.000Z", "100", "110", "90", "1O5", "2"}})
+	if !errors.Is(err, convert.ErrInvalidNumericField) {
+		t.Fatalf("expected %v, received %v", convert.ErrInvalidNumericField, err)
+	}
+	if !strings.Contains(err.Error(), `close value "1O5"`) {
+		t.Errorf("unexpected error message %v", err)
+	}
+}
+
+func TestGetTradingStatus(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/"+coinbeneAPIVersion+coinbeneGetAllPairs {
+			t.Errorf("unexpected path %v", r.URL.Path)
+		}
+		_, err := w.Write([]byte(`{"code":200,"data":[]}`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.SetDefaults()
+	cb.API.Endpoints.URL = server.URL + "/"
+	cb.Requester = request.New(cb.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	for i := 0; i < 2; i++ {
+		status, err := cb.GetTradingStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status != exchange.TradingStatusMaintenance {
+			t.Errorf("expected %v, received %v", exchange.TradingStatusMaintenance, status)
+		}
+	}
+	if r := atomic.LoadInt32(&requests); r != 1 {
+		t.Errorf("expected status to be cached after %v request, received %v", 1, r)
+	}
+
+	if s := tradingStatusFromPairs([]PairData{{Symbol: "BTC/USDT"}}); s != exchange.TradingStatusOpen {
+		t.Errorf("expected %v, received %v", exchange.TradingStatusOpen, s)
+	}
+}
+
+func TestUnsupportedFunctions(t *testing.T) {
+	t.Parallel()
+	var cb Coinbene
+	cb.SetDefaults()
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	_, err := cb.GetExchangeHistory(p, asset.Futures, time.Now().Add(-time.Hour), time.Now())
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v, received %v", common.ErrFunctionNotSupported, err)
+	}
+	_, err = cb.GetDepositAddress(currency.BTC, "")
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v, received %v", common.ErrFunctionNotSupported, err)
+	}
+	_, err = cb.ModifyOrder(&order.Modify{})
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v, received %v", common.ErrFunctionNotSupported, err)
+	}
+}
+
+func TestGetExchangeHistory(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.URL.Path {
+		case "/spot/" + coinbeneAPIVersion + coinbeneGetTrades:
+			_, err = w.Write([]byte(`{"code":200,"data":[["BTC/USDT","11400.5","0.2","buy","2020-10-17T10:00:00Z"],["BTC/USDT","11401","0.1","sell","2020-10-17T09:00:00Z"]]}`))
+		case "/swap/" + coinbeneAPIVersion + coinbeneGetTrades:
+			_, err = w.Write([]byte(`{"code":200,"data":[["11400.5","s","3","2020-10-17T10:00:00Z"]]}`))
+		default:
+			t.Errorf("unexpected path %v", r.URL.Path)
+		}
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var cb Coinbene
+	cb.SetDefaults()
+	cb.API.Endpoints.URL = server.URL + "/spot/"
+	cb.API.Endpoints.URLSecondary = server.URL + "/swap/"
+	cb.Requester = request.New(cb.Name, common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	cb.CurrencyPairs.StorePairs(asset.PerpetualSwap, currency.Pairs{p}, false)
+
+	start := time.Date(2020, 10, 17, 9, 30, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	trades, err := cb.GetExchangeHistory(p, asset.Spot, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 {
+		t.Fatalf("expected trades outside the range to be dropped, received %v", len(trades))
+	}
+	if trades[0].Price != 11400.5 || trades[0].Amount != 0.2 || trades[0].Side != order.Buy.String() {
+		t.Errorf("unexpected trade %+v", trades[0])
+	}
+
+	trades, err = cb.GetExchangeHistory(p, asset.PerpetualSwap, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || trades[0].Side != order.Sell.String() || trades[0].Amount != 3 {
+		t.Errorf("unexpected swap trades %+v", trades)
+	}
+}
+
+func TestSymbolRoundTrip(t *testing.T) {
+	t.Parallel()
+	var cb Coinbene
+	cb.SetDefaults()
+	p := currency.NewPairWithDelimiter("BTC", "USDT", "/")
+	for _, tc := range []struct {
+		a      asset.Item
+		symbol string
+	}{
+		{asset.Spot, "BTC/USDT"},
+		{asset.PerpetualSwap, "BTCUSDT"},
+	} {
+		cb.CurrencyPairs.StorePairs(tc.a, currency.Pairs{p}, false)
+		fPair, err := cb.FormatExchangeCurrency(p, tc.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fPair.String() != tc.symbol {
+			t.Errorf("%s: expected %v, received %v", tc.a, tc.symbol, fPair)
+		}
+		back, err := cb.symbolToPair(fPair.String(), tc.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !back.Equal(p) {
+			t.Errorf("%s: expected %v, received %v", tc.a, p, back)
+		}
+	}
+}
+
+func TestWsOrderbookSequenceGap(t *testing.T) {
+	t.Parallel()
+	var cb Coinbene
+	cb.SetDefaults()
+	cb.Websocket = sharedtestvalues.NewTestWebsocket()
+	cb.Websocket.Orderbook.Setup(0, false, false, false, false, cb.Name, cb.Websocket.DataHandler)
+	cb.CurrencyPairs.StorePairs(asset.PerpetualSwap,
+		currency.Pairs{currency.NewPairWithDelimiter("BTC", "USDT", "/")}, true)
+	format, err := cb.GetPairFormat(asset.PerpetualSwap, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs, err := cb.GetEnabledPairs(asset.PerpetualSwap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pair, err := currency.NewPairFromFormattedPairs("BTCUSDT", pairs, format)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resubscribed int
+	cb.Websocket.Unsubscriber = func(subs []stream.ChannelSubscription) error {
+		cb.Websocket.RemoveSuccessfulUnsubscriptions(subs...)
+		return nil
+	}
+	cb.Websocket.Subscriber = func(subs []stream.ChannelSubscription) error {
+		resubscribed++
+		cb.Websocket.AddSuccessfulSubscriptions(subs...)
+		return nil
+	}
+	cb.Websocket.AddSuccessfulSubscriptions(stream.ChannelSubscription{
+		Channel: "orderBook.BTCUSDT.100",
+		Asset:   asset.PerpetualSwap,
+	})
+
+	book := func(action string, version int) []byte {
+		return []byte(fmt.Sprintf(`{"topic":"orderBook.BTCUSDT","action":%q,"data":[{"asks":[["5621.7","58","2"]],"bids":[["5621.3","287","8"]],"version":%d,"timestamp":"2019-07-04T02:21:08Z"}]}`,
+			action, version))
+	}
+	for _, tc := range []struct {
+		action      string
+		version     int
+		resubscribe int
+		processed   int
+		flushed     bool
+	}{
+		{"insert", 10, 0, 1, false},
+		{"update", 11, 0, 1, false},
+		{"update", 13, 1, 0, true}, // version 12 missed
+		{"update", 14, 1, 0, true}, // dropped while awaiting a snapshot
+		{"insert", 20, 1, 1, false},
+		{"update", 21, 1, 1, false},
+	} {
+		err := cb.wsHandleData(book(tc.action, tc.version))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resubscribed != tc.resubscribe {
+			t.Errorf("%s %d: expected %v resubscriptions, received %v",
+				tc.action, tc.version, tc.resubscribe, resubscribed)
+		}
+		ob := cb.Websocket.Orderbook.GetOrderbook(pair, asset.PerpetualSwap)
+		if flushed := ob == nil; flushed != tc.flushed {
+			t.Errorf("%s %d: expected orderbook flushed %v, received %v",
+				tc.action, tc.version, tc.flushed, flushed)
+		}
+		if processed := len(cb.Websocket.DataHandler); processed != tc.processed {
+			t.Errorf("%s %d: expected %v processed, received %v",
+				tc.action, tc.version, tc.processed, processed)
+		}
+		for len(cb.Websocket.DataHandler) > 0 {
+			<-cb.Websocket.DataHandler
+		}
+	}
+}
+
+func TestSubAccounts(t *testing.T) {
+	t.Parallel()
+	spot := []UserBalanceData{
+		{Asset: "btc", Available: 1, Reserved: 0.5},
+		{Asset: "USDT", Available: 100},
+		{Asset: "BTC", Available: 0.25},
+	}
+	swap := &SwapAccountInfo{Balance: 250, AvailableBalance: 200, FrozenBalance: 50}
+
+	checkSpot := func(acc account.SubAccount) {
+		t.Helper()
+		if acc.ID != asset.Spot.String() || len(acc.Currencies) != 2 {
+			t.Fatalf("unexpected spot account %+v", acc)
+		}
+		if !acc.Currencies[0].CurrencyName.Match(currency.BTC) ||
+			acc.Currencies[0].TotalValue != 1.75 || acc.Currencies[0].Hold != 0.5 {
+			t.Errorf("unexpected BTC balance %+v", acc.Currencies[0])
+		}
+		if !acc.Currencies[1].CurrencyName.Match(currency.USDT) ||
+			acc.Currencies[1].TotalValue != 100 {
+			t.Errorf("unexpected USDT balance %+v", acc.Currencies[1])
+		}
+	}
+	checkSwap := func(acc account.SubAccount) {
+		t.Helper()
+		if acc.ID != asset.PerpetualSwap.String() || len(acc.Currencies) != 1 ||
+			!acc.Currencies[0].CurrencyName.Match(currency.USDT) ||
+			acc.Currencies[0].TotalValue != 250 || acc.Currencies[0].Hold != 50 {
+			t.Errorf("unexpected swap account %+v", acc)
+		}
+	}
+
+	accounts := subAccounts(spot, nil)
+	if len(accounts) != 1 {
+		t.Fatalf("expected %v, received %v", 1, len(accounts))
+	}
+	checkSpot(accounts[0])
+
+	accounts = subAccounts(nil, swap)
+	if len(accounts) != 1 {
+		t.Fatalf("expected %v, received %v", 1, len(accounts))
+	}
+	checkSwap(accounts[0])
+
+	accounts = subAccounts(spot, swap)
+	if len(accounts) != 2 {
+		t.Fatalf("expected %v, received %v", 2, len(accounts))
+	}
+	checkSpot(accounts[0])
+	checkSwap(accounts[1])
+}
+
+func TestCancelOrdersWithRetry(t *testing.T) {
+	t.Parallel()
+	var ids []string
+	for x := 0; x < 12; x++ {
+		ids = append(ids, strconv.Itoa(x))
+	}
+	seen := make(map[string]int)
+	var calls [][]string
+	cancel := func(batch []string) ([]OrderCancellationResponse, error) {
+		calls = append(calls, append([]string(nil), batch...))
+		var resp []OrderCancellationResponse
+		for x := range batch {
+			seen[batch[x]]++
+			switch {
+			case batch[x] == "5":
+				resp = append(resp, OrderCancellationResponse{OrderID: batch[x], Code: 10311, Message: "order not found"})
+			case batch[x] == "2" && seen[batch[x]] == 1:
+				resp = append(resp, OrderCancellationResponse{OrderID: batch[x], Code: 500, Message: "system busy"})
+			case batch[x] == "11" && seen[batch[x]] == 1:
+				// omitted from the response
+			default:
+				resp = append(resp, OrderCancellationResponse{OrderID: batch[x], Code: 200})
+			}
+		}
+		return resp, nil
+	}
+
+	results, err := cancelOrdersWithRetry(ids, 3, cancel)
+	if !errors.Is(err, errOrdersNotCancelled) {
+		t.Errorf("expected %v, received %v", errOrdersNotCancelled, err)
+	}
+	// Order 5 is unknown to the exchange so it is never resubmitted
+	expected := [][]string{ids[:10], ids[10:], {"2", "11"}}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected %v, received %v", expected, calls)
+	}
+	for x := range expected {
+		if strings.Join(calls[x], ",") != strings.Join(expected[x], ",") {
+			t.Errorf("call %d: expected %v, received %v", x, expected[x], calls[x])
+		}
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("expected %v, received %v", len(ids), len(results))
+	}
+	for id, r := range results {
+		if id == "5" {
+			if r.Code != 10311 {
+				t.Errorf("expected %v, received %v", 10311, r.Code)
+			}
+			continue
+		}
+		if r.Code != 200 {
+			t.Errorf("%s: expected %v, received %v", id, 200, r.Code)
+		}
+	}
+
+	calls = nil
+	_, err = cancelOrdersWithRetry([]string{"1", "2"}, 2, func(batch []string) ([]OrderCancellationResponse, error) {
+		calls = append(calls, batch)
+		if len(calls) == 1 {
+			return nil, errors.New("connection reset")
+		}
+		return []OrderCancellationResponse{{OrderID: "1", Code: 200}, {OrderID: "2", Code: 200}}, nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("expected %v, received %v", 2, len(calls))
+	}
+}
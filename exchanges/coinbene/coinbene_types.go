@@ -1,6 +1,7 @@
 package coinbene
 
 import (
+	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
@@ -17,6 +18,11 @@ type TickerData struct {
 	DailyVolume float64 `json:"volume24h,string"`
 }
 
+// spotTickers stores the spot ticker list response
+type spotTickers struct {
+	TickerData []TickerData `json:"data"`
+}
+
 // OrderbookItem stores an individual orderbook item
 type OrderbookItem struct {
 	Price  float64
@@ -44,6 +50,31 @@ type TradeItem struct {
 // Trades stores trade data
 type Trades []TradeItem
 
+// RequestCategory groups Coinbene REST endpoints so each group can be given
+// its own timeout
+type RequestCategory uint8
+
+// Request categories, account queries fall under MarketDataRequests
+const (
+	MarketDataRequests RequestCategory = iota
+	OrderRequests
+	HistoryRequests
+)
+
+// requestTimeouts holds the timeouts set per request category
+type requestTimeouts struct {
+	m        sync.RWMutex
+	timeouts map[RequestCategory]time.Duration
+}
+
+// tradablePairs caches the spot pairs returned by GetAllPairs so orders for
+// unknown symbols can be rejected before they are sent
+type tradablePairs struct {
+	m       sync.RWMutex
+	pairs   map[string]PairData
+	updated time.Time
+}
+
 // PairData stores pair data
 type PairData struct {
 	Symbol           string  `json:"symbol"`
@@ -229,9 +260,17 @@ type SwapTicker struct {
 	BestAskVolume float64   `json:"bestAskVolume,string"`
 	BestBidVolume float64   `json:"bestBidVolume,string"`
 	Turnover      float64   `json:"turnover,string"`
+	OpenInterest  float64   `json:"openInterest,string"`
 	Timestamp     time.Time `json:"timeStamp"`
 }
 
+// SwapMarkPrice stores the mark price of a swap symbol
+type SwapMarkPrice struct {
+	Symbol    string
+	MarkPrice float64
+	Timestamp time.Time
+}
+
 // SwapTickers stores a map of swap tickers
 type SwapTickers map[string]SwapTicker
 
@@ -361,3 +400,11 @@ type CandleResponse struct {
 	Message string          `json:"message"`
 	Data    [][]interface{} `json:"data"`
 }
+
+// orderbookSequences stores the last applied websocket orderbook version per
+// symbol, a symbol set to awaitingSnapshot drops deltas until a fresh
+// snapshot arrives
+type orderbookSequences struct {
+	m    sync.Mutex
+	last map[string]int64
+}
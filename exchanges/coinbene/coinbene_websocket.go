@@ -19,14 +19,22 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
 const (
 	wsContractURL = "wss://ws-contract.coinbene.vip/openapi/ws"
 	event         = "event"
 	topic         = "topic"
+	wsLoginPath   = "/login"
+
+	// awaitingSnapshot marks an orderbook whose deltas are dropped until a
+	// fresh snapshot is received
+	awaitingSnapshot = -1
 )
 
+var errWsLoginRejected = errors.New("websocket login rejected")
+
 // WsConnect connects to websocket
 func (c *Coinbene) WsConnect() error {
 	if !c.Websocket.IsEnabled() || !c.IsEnabled() {
@@ -38,6 +46,7 @@ func (c *Coinbene) WsConnect() error {
 		return err
 	}
 
+	c.obSequences.reset()
 	go c.wsReadData()
 	if c.GetAuthenticatedAPISupport(exchange.WebsocketAuthentication) {
 		err = c.Login()
@@ -123,7 +132,7 @@ func (c *Coinbene) wsHandleData(respRaw []byte) error {
 		return fmt.Errorf("message: %s. code: %v", result["message"], result["code"])
 	}
 	if ok && strings.Contains(result[event].(string), "login") {
-		if result["success"].(bool) {
+		if success, _ := result["success"].(bool); success {
 			c.Websocket.SetCanUseAuthenticatedEndpoints(true)
 			var authsubs []stream.ChannelSubscription
 			authsubs, err = c.GenerateAuthSubs()
@@ -133,7 +142,11 @@ func (c *Coinbene) wsHandleData(respRaw []byte) error {
 			return c.Websocket.SubscribeToChannels(authsubs)
 		}
 		c.Websocket.SetCanUseAuthenticatedEndpoints(false)
-		return fmt.Errorf("message: %s. code: %v", result["message"], result["code"])
+		return fmt.Errorf("%s %w. message: %s. code: %v",
+			c.Name,
+			errWsLoginRejected,
+			result["message"],
+			result["code"])
 	}
 	switch {
 	case strings.Contains(result[topic].(string), "ticker"):
@@ -295,6 +308,7 @@ func (c *Coinbene) wsHandleData(respRaw []byte) error {
 			})
 		}
 		if orderBook.Action == "insert" {
+			c.obSequences.snapshot(p, orderBook.Data[0].Version)
 			var newOB orderbook.Base
 			newOB.Asks = asks
 			newOB.Bids = bids
@@ -307,6 +321,19 @@ func (c *Coinbene) wsHandleData(respRaw []byte) error {
 				return err
 			}
 		} else if orderBook.Action == "update" {
+			apply, resync := c.obSequences.update(p, orderBook.Data[0].Version)
+			if resync {
+				log.Warnf(log.WebsocketMgr,
+					"%s websocket orderbook %s sequence gap at version %d, requesting a fresh snapshot\n",
+					c.Name,
+					p,
+					orderBook.Data[0].Version)
+				c.Websocket.Orderbook.FlushOrderbook(newP, asset.PerpetualSwap)
+				return c.resubscribeOrderbook(p)
+			}
+			if !apply {
+				return nil
+			}
 			newOB := buffer.Update{
 				Asks:       asks,
 				Bids:       bids,
@@ -451,6 +478,60 @@ func (c *Coinbene) wsHandleData(respRaw []byte) error {
 	return nil
 }
 
+// resubscribeOrderbook resubscribes to a symbol's orderbook channel so the
+// exchange sends a fresh snapshot to replace the flushed local book
+func (c *Coinbene) resubscribeOrderbook(symbol string) error {
+	subs := c.Websocket.GetSubscriptions()
+	for x := range subs {
+		if strings.HasPrefix(subs[x].Channel, "orderBook."+symbol) {
+			return c.Websocket.ResubscribeToChannel(&subs[x])
+		}
+	}
+	return fmt.Errorf("%s websocket: orderbook subscription for %s not found",
+		c.Name,
+		symbol)
+}
+
+// reset clears all tracked versions, used when the connection is
+// re-established as every subscription is snapshotted again
+func (o *orderbookSequences) reset() {
+	o.m.Lock()
+	o.last = nil
+	o.m.Unlock()
+}
+
+// snapshot records the version of a freshly loaded orderbook snapshot
+func (o *orderbookSequences) snapshot(symbol string, version int64) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.last == nil {
+		o.last = make(map[string]int64)
+	}
+	o.last[symbol] = version
+}
+
+// update records an orderbook delta version, returning whether the delta
+// follows the last applied version and can be applied, or whether a version
+// was skipped and a fresh snapshot is needed. Deltas received while awaiting
+// a snapshot are dropped without requesting another
+func (o *orderbookSequences) update(symbol string, version int64) (apply, resync bool) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.last == nil {
+		o.last = make(map[string]int64)
+	}
+	last, ok := o.last[symbol]
+	switch {
+	case ok && last == awaitingSnapshot:
+		return false, false
+	case !ok, version != last+1:
+		o.last[symbol] = awaitingSnapshot
+		return false, true
+	}
+	o.last[symbol] = version
+	return true, false
+}
+
 // Subscribe sends a websocket message to receive data from the channel
 func (c *Coinbene) Subscribe(channelsToSubscribe []stream.ChannelSubscription) error {
 	var sub WsSub
@@ -481,16 +562,26 @@ func (c *Coinbene) Unsubscribe(channelToUnsubscribe []stream.ChannelSubscription
 	return nil
 }
 
-// Login logs in
+// Login sends a signed login frame which is required before subscribing to
+// private user channels. The login outcome is processed by wsHandleData
 func (c *Coinbene) Login() error {
-	var sub WsSub
-	expTime := time.Now().Add(time.Minute * 10).Format("2006-01-02T15:04:05Z")
-	signMsg := expTime + http.MethodGet + "/login"
+	if !c.AllowAuthenticatedRequest() {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
+			c.Name)
+	}
+	expTime := time.Now().Add(time.Minute * 10).UTC().Format("2006-01-02T15:04:05Z")
+	sub := WsSub{
+		Operation: "login",
+		Arguments: []string{c.API.Credentials.Key, expTime, c.wsLoginSignature(expTime)},
+	}
+	return c.Websocket.Conn.SendJSONMessage(sub)
+}
+
+// wsLoginSignature signs the login prehash string which consists of the
+// expiry time, request method and login path
+func (c *Coinbene) wsLoginSignature(expTime string) string {
 	tempSign := crypto.GetHMAC(crypto.HashSHA256,
-		[]byte(signMsg),
+		[]byte(expTime+http.MethodGet+wsLoginPath),
 		[]byte(c.API.Credentials.Secret))
-	sign := crypto.HexEncodeToString(tempSign)
-	sub.Operation = "login"
-	sub.Arguments = []string{c.API.Credentials.Key, expTime, sign}
-	return c.Websocket.Conn.SendJSONMessage(sub)
+	return crypto.HexEncodeToString(tempSign)
 }
@@ -3,12 +3,14 @@ package coinbene
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -99,6 +101,7 @@ func (c *Coinbene) SetDefaults() {
 				CancelOrders:      true,
 				SubmitOrder:       true,
 				TradeFee:          true,
+				KlineFetching:     true,
 			},
 			WebsocketCapabilities: protocol.Features{
 				TickerFetching:         true,
@@ -138,15 +141,20 @@ func (c *Coinbene) SetDefaults() {
 					kline.ThreeDay.Word():   true,
 					kline.OneWeek.Word():    true,
 				},
+				ResultLimit: coinbeneKlineLimit,
 			},
 		},
 	}
 	c.Requester = request.New(c.Name,
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout),
-		request.WithLimiter(SetRateLimit()))
+		request.WithLimiter(SetRateLimit()),
+		request.WithRetryPolicy(retryPolicy),
+		request.WithBackoff(request.ExponentialBackoff(retryBackoffBase, retryBackoffMax)))
 
 	c.API.Endpoints.URLDefault = coinbeneAPIURL
 	c.API.Endpoints.URL = c.API.Endpoints.URLDefault
+	c.API.Endpoints.URLSecondaryDefault = coinbeneSwapAPIURL
+	c.API.Endpoints.URLSecondary = c.API.Endpoints.URLSecondaryDefault
 	c.API.Endpoints.WebsocketURL = wsContractURL
 	c.Websocket = stream.New()
 	c.WebsocketResponseMaxLimit = exchange.DefaultWebsocketResponseMaxLimit
@@ -233,17 +241,14 @@ func (c *Coinbene) FetchTradablePairs(a asset.Item) ([]string, error) {
 		return nil, fmt.Errorf("%s does not support asset type %s", c.Name, a)
 	}
 
-	var currencies []string
 	switch a {
 	case asset.Spot:
 		pairs, err := c.GetAllPairs()
 		if err != nil {
 			return nil, err
 		}
-
-		for x := range pairs {
-			currencies = append(currencies, pairs[x].Symbol)
-		}
+		c.setTradablePairs(pairs)
+		return spotTradablePairs(pairs), nil
 	case asset.PerpetualSwap:
 		format, err := c.GetPairFormat(a, false)
 		if err != nil {
@@ -254,16 +259,51 @@ func (c *Coinbene) FetchTradablePairs(a asset.Item) ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
-		for t := range tickers {
-			idx := strings.Index(t, currency.USDT.String())
-			if idx == 0 {
-				return nil,
-					fmt.Errorf("%s SWAP currency does not contain USDT", c.Name)
-			}
-			currencies = append(currencies,
-				t[0:idx]+format.Delimiter+t[idx:])
+		return swapTradablePairs(tickers, format.Delimiter)
+	}
+	return nil, nil
+}
+
+// symbolToPair converts a Coinbene symbol back into a currency pair by
+// matching it against the available pairs in the asset's request format, so
+// undelimited swap symbols such as BTCUSDT resolve to their base and quote
+func (c *Coinbene) symbolToPair(symbol string, a asset.Item) (currency.Pair, error) {
+	pairFmt, err := c.GetPairFormat(a, true)
+	if err != nil {
+		return currency.Pair{}, err
+	}
+	pairs, err := c.GetAvailablePairs(a)
+	if err != nil {
+		return currency.Pair{}, err
+	}
+	return currency.NewPairFromFormattedPairs(symbol, pairs, pairFmt)
+}
+
+// spotTradablePairs returns the symbols of the supplied spot pairs
+func spotTradablePairs(pairs []PairData) []string {
+	currencies := make([]string, 0, len(pairs))
+	for x := range pairs {
+		currencies = append(currencies, pairs[x].Symbol)
+	}
+	return currencies
+}
+
+// swapTradablePairs returns sorted, delimited USDT swap pairs, skipping
+// contracts without a last or mark price as they are no longer trading
+func swapTradablePairs(tickers SwapTickers, delimiter string) ([]string, error) {
+	currencies := make([]string, 0, len(tickers))
+	for t := range tickers {
+		if tickers[t].LastPrice == 0 && tickers[t].MarkPrice == 0 {
+			continue
+		}
+		idx := strings.Index(t, currency.USDT.String())
+		if idx <= 0 {
+			return nil,
+				fmt.Errorf("SWAP currency %s does not contain USDT", t)
 		}
+		currencies = append(currencies, t[0:idx]+delimiter+t[idx:])
 	}
+	sort.Strings(currencies)
 	return currencies, nil
 }
 
@@ -290,6 +330,20 @@ func (c *Coinbene) UpdateTradablePairs(forceUpdate bool) error {
 	return nil
 }
 
+// GetOpenInterest returns the open interest in contracts for a perpetual
+// swap pair
+func (c *Coinbene) GetOpenInterest(pair currency.Pair) (float64, error) {
+	fPair, err := c.FormatExchangeCurrency(pair, asset.PerpetualSwap)
+	if err != nil {
+		return 0, err
+	}
+	t, err := c.GetSwapTicker(fPair.String())
+	if err != nil {
+		return 0, err
+	}
+	return t.OpenInterest, nil
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (c *Coinbene) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
 	if !c.SupportsAsset(assetType) {
@@ -311,7 +365,7 @@ func (c *Coinbene) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 
 		for i := range tickers {
 			var newP currency.Pair
-			newP, err = currency.NewPairFromString(tickers[i].Symbol)
+			newP, err = c.symbolToPair(tickers[i].Symbol, asset.Spot)
 			if err != nil {
 				return nil, err
 			}
@@ -320,16 +374,7 @@ func (c *Coinbene) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
-				Pair:         newP,
-				Last:         tickers[i].LatestPrice,
-				High:         tickers[i].DailyHigh,
-				Low:          tickers[i].DailyLow,
-				Bid:          tickers[i].BestBid,
-				Ask:          tickers[i].BestAsk,
-				Volume:       tickers[i].DailyVolume,
-				ExchangeName: c.Name,
-				AssetType:    assetType})
+			err = c.processSpotTicker(&tickers[i], newP)
 			if err != nil {
 				return nil, err
 			}
@@ -354,17 +399,7 @@ func (c *Coinbene) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
-				Pair:         allPairs[x],
-				Last:         tick.LastPrice,
-				High:         tick.High24Hour,
-				Low:          tick.Low24Hour,
-				Bid:          tick.BestBidPrice,
-				Ask:          tick.BestAskPrice,
-				Volume:       tick.Volume24Hour,
-				LastUpdated:  tick.Timestamp,
-				ExchangeName: c.Name,
-				AssetType:    assetType})
+			err = c.processSwapTicker(&tick, allPairs[x])
 			if err != nil {
 				return nil, err
 			}
@@ -373,6 +408,37 @@ func (c *Coinbene) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 	return ticker.GetTicker(c.Name, p, assetType)
 }
 
+// processSpotTicker converts a spot ticker and stores it in the ticker
+// package
+func (c *Coinbene) processSpotTicker(t *TickerData, p currency.Pair) error {
+	return ticker.ProcessTicker(&ticker.Price{
+		Pair:         p,
+		Last:         t.LatestPrice,
+		High:         t.DailyHigh,
+		Low:          t.DailyLow,
+		Bid:          t.BestBid,
+		Ask:          t.BestAsk,
+		Volume:       t.DailyVolume,
+		ExchangeName: c.Name,
+		AssetType:    asset.Spot})
+}
+
+// processSwapTicker converts a perpetual swap ticker and stores it in the
+// ticker package
+func (c *Coinbene) processSwapTicker(t *SwapTicker, p currency.Pair) error {
+	return ticker.ProcessTicker(&ticker.Price{
+		Pair:         p,
+		Last:         t.LastPrice,
+		High:         t.High24Hour,
+		Low:          t.Low24Hour,
+		Bid:          t.BestBidPrice,
+		Ask:          t.BestAskPrice,
+		Volume:       t.Volume24Hour,
+		LastUpdated:  t.Timestamp,
+		ExchangeName: c.Name,
+		AssetType:    asset.PerpetualSwap})
+}
+
 // FetchTicker returns the ticker for a currency pair
 func (c *Coinbene) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
 	if !c.SupportsAsset(assetType) {
@@ -403,7 +469,6 @@ func (c *Coinbene) FetchOrderbook(p currency.Pair, assetType asset.Item) (*order
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (c *Coinbene) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	resp := new(orderbook.Base)
 	if !c.SupportsAsset(assetType) {
 		return nil,
 			fmt.Errorf("%s does not support asset type %s", c.Name, assetType)
@@ -418,70 +483,78 @@ func (c *Coinbene) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orde
 	switch assetType {
 	case asset.Spot:
 		tempResp, err = c.GetOrderbook(fpair.String(),
-			100, // TO-DO: Update this once we support configurable orderbook depth
+			defaultOrderbookDepth,
 		)
 	case asset.PerpetualSwap:
 		tempResp, err = c.GetSwapOrderbook(fpair.String(),
-			100, // TO-DO: Update this once we support configurable orderbook depth
+			defaultOrderbookDepth,
 		)
 	}
 	if err != nil {
 		return nil, err
 	}
-	resp.ExchangeName = c.Name
-	resp.Pair = p
-	resp.AssetType = assetType
-	for x := range tempResp.Asks {
+	err = c.convertOrderbook(&tempResp, p, assetType).Process()
+	if err != nil {
+		return nil, err
+	}
+	return orderbook.Get(c.Name, p, assetType)
+}
+
+// convertOrderbook converts a spot or swap orderbook to an orderbook.Base,
+// carrying the swap order count where present
+func (c *Coinbene) convertOrderbook(ob *Orderbook, p currency.Pair, assetType asset.Item) *orderbook.Base {
+	resp := &orderbook.Base{
+		ExchangeName: c.Name,
+		Pair:         p,
+		AssetType:    assetType,
+	}
+	for x := range ob.Asks {
 		item := orderbook.Item{
-			Price:  tempResp.Asks[x].Price,
-			Amount: tempResp.Asks[x].Amount,
+			Price:  ob.Asks[x].Price,
+			Amount: ob.Asks[x].Amount,
 		}
 		if assetType == asset.PerpetualSwap {
-			item.OrderCount = tempResp.Asks[x].Count
+			item.OrderCount = ob.Asks[x].Count
 		}
 		resp.Asks = append(resp.Asks, item)
 	}
-	for x := range tempResp.Bids {
+	for x := range ob.Bids {
 		item := orderbook.Item{
-			Price:  tempResp.Bids[x].Price,
-			Amount: tempResp.Bids[x].Amount,
+			Price:  ob.Bids[x].Price,
+			Amount: ob.Bids[x].Amount,
 		}
 		if assetType == asset.PerpetualSwap {
-			item.OrderCount = tempResp.Bids[x].Count
+			item.OrderCount = ob.Bids[x].Count
 		}
 		resp.Bids = append(resp.Bids, item)
 	}
-	err = resp.Process()
-	if err != nil {
-		return nil, err
-	}
-	return orderbook.Get(c.Name, p, assetType)
+	return resp
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
 // Coinbene exchange
 func (c *Coinbene) UpdateAccountInfo() (account.Holdings, error) {
 	var info account.Holdings
-	balance, err := c.GetAccountBalances()
-	if err != nil {
-		return info, err
-	}
-	var acc account.SubAccount
-	for key := range balance {
-		c := currency.NewCode(balance[key].Asset)
-		hold := balance[key].Reserved
-		available := balance[key].Available
-		acc.Currencies = append(acc.Currencies,
-			account.Balance{
-				CurrencyName: c,
-				TotalValue:   hold + available,
-				Hold:         hold,
-			})
+	var spot []UserBalanceData
+	var swap *SwapAccountInfo
+	if c.CurrencyPairs.IsAssetEnabled(asset.Spot) == nil {
+		balance, err := c.GetAccountBalances()
+		if err != nil {
+			return info, err
+		}
+		spot = balance
+	}
+	if c.CurrencyPairs.IsAssetEnabled(asset.PerpetualSwap) == nil {
+		swapInfo, err := c.GetSwapAccountInfo()
+		if err != nil {
+			return info, err
+		}
+		swap = &swapInfo
 	}
-	info.Accounts = append(info.Accounts, acc)
+	info.Accounts = subAccounts(spot, swap)
 	info.Exchange = c.Name
 
-	err = account.Process(&info)
+	err := account.Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -489,6 +562,48 @@ func (c *Coinbene) UpdateAccountInfo() (account.Holdings, error) {
 	return info, nil
 }
 
+// subAccounts merges spot balances and the USDT margined swap account into
+// a sub account per asset type. Spot currency codes are upper cased and
+// repeated codes summed
+func subAccounts(spot []UserBalanceData, swap *SwapAccountInfo) []account.SubAccount {
+	var accounts []account.SubAccount
+	if len(spot) > 0 {
+		acc := account.SubAccount{ID: asset.Spot.String()}
+		index := make(map[string]int)
+		for x := range spot {
+			code := strings.ToUpper(strings.TrimSpace(spot[x].Asset))
+			if code == "" {
+				continue
+			}
+			hold := spot[x].Reserved
+			total := hold + spot[x].Available
+			if i, ok := index[code]; ok {
+				acc.Currencies[i].TotalValue += total
+				acc.Currencies[i].Hold += hold
+				continue
+			}
+			index[code] = len(acc.Currencies)
+			acc.Currencies = append(acc.Currencies, account.Balance{
+				CurrencyName: currency.NewCode(code),
+				TotalValue:   total,
+				Hold:         hold,
+			})
+		}
+		accounts = append(accounts, acc)
+	}
+	if swap != nil {
+		accounts = append(accounts, account.SubAccount{
+			ID: asset.PerpetualSwap.String(),
+			Currencies: []account.Balance{{
+				CurrencyName: currency.USDT,
+				TotalValue:   swap.Balance,
+				Hold:         swap.FrozenBalance,
+			}},
+		})
+	}
+	return accounts
+}
+
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (c *Coinbene) FetchAccountInfo() (account.Holdings, error) {
 	acc, err := account.GetHoldings(c.Name)
@@ -500,14 +615,64 @@ func (c *Coinbene) FetchAccountInfo() (account.Holdings, error) {
 }
 
 // GetFundingHistory returns funding history, deposits and
-// withdrawals
+// withdrawals, Coinbene only offers these through its website
 func (c *Coinbene) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return nil, common.ErrFunctionNotSupported
 }
 
 // GetExchangeHistory returns historic trade data within the timeframe provided.
+// Coinbene only serves its most recent public trades so anything older is not
+// returned
 func (c *Coinbene) GetExchangeHistory(p currency.Pair, assetType asset.Item, timestampStart, timestampEnd time.Time) ([]exchange.TradeHistory, error) {
-	return nil, common.ErrFunctionNotSupported
+	if assetType != asset.Spot && assetType != asset.PerpetualSwap {
+		return nil, fmt.Errorf("%w: asset %v", common.ErrFunctionNotSupported, assetType)
+	}
+
+	fPair, err := c.FormatExchangeCurrency(p, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []exchange.TradeHistory
+	if assetType == asset.Spot {
+		trades, err := c.GetTrades(fPair.String())
+		if err != nil {
+			return nil, err
+		}
+		for x := range trades {
+			resp = append(resp, exchange.TradeHistory{
+				Timestamp: trades[x].TradeTime,
+				Price:     trades[x].Price,
+				Amount:    trades[x].Volume,
+				Exchange:  c.Name,
+				Side:      strings.ToUpper(trades[x].Direction),
+			})
+		}
+	} else {
+		trades, err := c.GetSwapTrades(fPair.String(), 0)
+		if err != nil {
+			return nil, err
+		}
+		for x := range trades {
+			resp = append(resp, exchange.TradeHistory{
+				Timestamp: trades[x].Time,
+				Price:     trades[x].Price,
+				Amount:    trades[x].Volume,
+				Exchange:  c.Name,
+				Side:      trades[x].Side.String(),
+			})
+		}
+	}
+
+	filtered := resp[:0]
+	for x := range resp {
+		if (!timestampStart.IsZero() && resp[x].Timestamp.Before(timestampStart)) ||
+			(!timestampEnd.IsZero() && resp[x].Timestamp.After(timestampEnd)) {
+			continue
+		}
+		filtered = append(filtered, resp[x])
+	}
+	return filtered, nil
 }
 
 // SubmitOrder submits a new order
@@ -528,6 +693,11 @@ func (c *Coinbene) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
 		return resp, err
 	}
 
+	err = c.checkTradablePair(fpair.String())
+	if err != nil {
+		return resp, err
+	}
+
 	tempResp, err := c.PlaceSpotOrder(s.Price,
 		s.Amount,
 		fpair.String(),
@@ -544,7 +714,7 @@ func (c *Coinbene) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
 }
 
 // ModifyOrder will allow of changing orderbook placement and limit to
-// market conversion
+// market conversion, Coinbene has no order amendment endpoint
 func (c *Coinbene) ModifyOrder(action *order.Modify) (string, error) {
 	return "", common.ErrFunctionNotSupported
 }
@@ -601,7 +771,8 @@ func (c *Coinbene) GetOrderInfo(orderID string) (order.Detail, error) {
 	return resp, nil
 }
 
-// GetDepositAddress returns a deposit address for a specified currency
+// GetDepositAddress returns a deposit address for a specified currency,
+// Coinbene only offers deposits through its website
 func (c *Coinbene) GetDepositAddress(cryptocurrency currency.Code, accountID string) (string, error) {
 	return "", common.ErrFunctionNotSupported
 }
@@ -632,7 +803,7 @@ func (c *Coinbene) GetActiveOrders(getOrdersRequest *order.GetOrdersRequest) ([]
 			return nil, err
 		}
 		for a := range allPairs {
-			p, err := currency.NewPairFromString(allPairs[a].Symbol)
+			p, err := c.symbolToPair(allPairs[a].Symbol, asset.Spot)
 			if err != nil {
 				return nil, err
 			}
@@ -663,7 +834,7 @@ func (c *Coinbene) GetActiveOrders(getOrdersRequest *order.GetOrdersRequest) ([]
 				tempResp.Side = order.Sell
 			}
 			tempResp.Date = tempData[y].OrderTime
-			tempResp.Status = order.Status(tempData[y].OrderStatus)
+			tempResp.Status = coinbeneStatus(tempData[y].OrderStatus)
 			tempResp.Price = tempData[y].OrderPrice
 			tempResp.Amount = tempData[y].Amount
 			tempResp.ExecutedAmount = tempData[y].FilledAmount
@@ -685,7 +856,7 @@ func (c *Coinbene) GetOrderHistory(getOrdersRequest *order.GetOrdersRequest) ([]
 		}
 
 		for a := range allPairs {
-			p, err := currency.NewPairFromString(allPairs[a].Symbol)
+			p, err := c.symbolToPair(allPairs[a].Symbol, asset.Spot)
 			if err != nil {
 				return nil, err
 			}
@@ -716,7 +887,7 @@ func (c *Coinbene) GetOrderHistory(getOrdersRequest *order.GetOrdersRequest) ([]
 				tempResp.Side = order.Sell
 			}
 			tempResp.Date = tempData[y].OrderTime
-			tempResp.Status = order.Status(tempData[y].OrderStatus)
+			tempResp.Status = coinbeneStatus(tempData[y].OrderStatus)
 			tempResp.Price = tempData[y].OrderPrice
 			tempResp.Amount = tempData[y].Amount
 			tempResp.ExecutedAmount = tempData[y].FilledAmount
@@ -728,22 +899,29 @@ func (c *Coinbene) GetOrderHistory(getOrdersRequest *order.GetOrdersRequest) ([]
 	return resp, nil
 }
 
-// GetFeeByType returns an estimate of fee based on the type of transaction
-func (c *Coinbene) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error) {
-	fpair, err := c.FormatExchangeCurrency(feeBuilder.Pair, asset.Spot)
-	if err != nil {
-		return 0, err
-	}
-
-	tempData, err := c.GetPairInfo(fpair.String())
-	if err != nil {
-		return 0, err
+// coinbeneStatus converts a Coinbene order status to an order.Status
+func coinbeneStatus(status string) order.Status {
+	switch strings.ToLower(status) {
+	case "new", "open":
+		return order.New
+	case "partiallyfilled":
+		return order.PartiallyFilled
+	case "filled":
+		return order.Filled
+	case "partiallycancelled", "partiallycanceled":
+		return order.PartiallyCancelled
+	case "cancelled", "canceled":
+		return order.Cancelled
+	case "rejected":
+		return order.Rejected
+	default:
+		return order.UnknownStatus
 	}
+}
 
-	if feeBuilder.IsMaker {
-		return feeBuilder.PurchasePrice * feeBuilder.Amount * tempData.MakerFeeRate, nil
-	}
-	return feeBuilder.PurchasePrice * feeBuilder.Amount * tempData.TakerFeeRate, nil
+// GetFeeByType returns an estimate of fee based on the type of transaction
+func (c *Coinbene) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	return c.GetFee(feeBuilder)
 }
 
 // AuthenticateWebsocket sends an authentication message to the websocket
@@ -780,21 +958,16 @@ func (c *Coinbene) GetHistoricCandles(pair currency.Pair, a asset.Item, start, e
 		return kline.Item{}, err
 	}
 
-	formattedPair, err := c.FormatExchangeCurrency(pair, asset.PerpetualSwap)
+	if kline.TotalCandlesPerInterval(start, end, interval) > c.Features.Enabled.Kline.GetResultLimit(a) {
+		return kline.Item{}, errors.New(kline.ErrRequestExceedsExchangeLimits)
+	}
+
+	formattedPair, err := c.FormatExchangeCurrency(pair, a)
 	if err != nil {
 		return kline.Item{}, err
 	}
 
-	var candles CandleResponse
-	if a == asset.PerpetualSwap {
-		candles, err = c.GetSwapKlines(formattedPair.String(),
-			start, end,
-			c.FormatExchangeKlineInterval(interval))
-	} else {
-		candles, err = c.GetKlines(formattedPair.String(),
-			start, end,
-			c.FormatExchangeKlineInterval(interval))
-	}
+	candles, err := c.fetchCandles(formattedPair.String(), a, start, end, interval)
 	if err != nil {
 		return kline.Item{}, err
 	}
@@ -804,68 +977,178 @@ func (c *Coinbene) GetHistoricCandles(pair currency.Pair, a asset.Item, start, e
 		Pair:     pair,
 		Interval: interval,
 		Asset:    a,
+		Candles:  candles,
 	}
+	ret.SortCandlesByTimestamp(false)
+	return ret, nil
+}
 
-	for x := range candles.Data {
-		var tempCandle kline.Candle
-		tempTime := candles.Data[x][0].(string)
-		timestamp, err := time.Parse(time.RFC3339, tempTime)
-		if err != nil {
-			continue
+// parseKlines converts raw kline rows into kline items. Rows hold the time,
+// open, high, low, close and volume, swap rows additionally hold the turnover,
+// buy volume and buy turnover which are parsed when present. Rows with an
+// invalid time are skipped
+func parseKlines(data [][]interface{}) (SwapKlines, error) {
+	klines := make(SwapKlines, 0, len(data))
+	for x := range data {
+		if len(data[x]) < 6 {
+			return nil, fmt.Errorf("%w: expected at least 6 fields, received %d",
+				errInvalidKlineData,
+				len(data[x]))
 		}
-		tempCandle.Time = timestamp
-		open, ok := candles.Data[x][1].(string)
+		tempTime, ok := data[x][0].(string)
 		if !ok {
-			return kline.Item{}, errors.New("open conversion failed")
+			continue
 		}
-		tempCandle.Open, err = strconv.ParseFloat(open, 64)
+		timestamp, err := time.Parse(time.RFC3339, tempTime)
 		if err != nil {
-			return kline.Item{}, err
+			continue
 		}
-		high, ok := candles.Data[x][2].(string)
-		if !ok {
-			return kline.Item{}, errors.New("high conversion failed")
+		values := make([]float64, len(data[x])-1)
+		if len(values) > len(klineFields) {
+			values = values[:len(klineFields)]
 		}
-		tempCandle.High, err = strconv.ParseFloat(high, 64)
-		if err != nil {
-			return kline.Item{}, err
+		for y := range values {
+			str, ok := data[x][y+1].(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s conversion failed",
+					errInvalidKlineData,
+					klineFields[y])
+			}
+			values[y], err = convert.FloatFromField(klineFields[y], str)
+			if err != nil {
+				return nil, err
+			}
 		}
-
-		low, ok := candles.Data[x][3].(string)
-		if !ok {
-			return kline.Item{}, errors.New("low conversion failed")
+		item := SwapKlineItem{
+			Time:   timestamp,
+			Open:   values[0],
+			High:   values[1],
+			Low:    values[2],
+			Close:  values[3],
+			Volume: values[4],
 		}
-		tempCandle.Low, err = strconv.ParseFloat(low, 64)
-		if err != nil {
-			return kline.Item{}, err
+		if len(values) > 5 {
+			item.Turnover = values[5]
 		}
-
-		closeTemp, ok := candles.Data[x][4].(string)
-		if !ok {
-			return kline.Item{}, errors.New("close conversion failed")
+		if len(values) > 6 {
+			item.BuyVolume = values[6]
 		}
-		tempCandle.Close, err = strconv.ParseFloat(closeTemp, 64)
-		if err != nil {
-			return kline.Item{}, err
+		if len(values) > 7 {
+			item.BuyTurnover = values[7]
 		}
+		klines = append(klines, item)
+	}
+	return klines, nil
+}
 
-		vol, ok := candles.Data[x][5].(string)
-		if !ok {
-			return kline.Item{}, errors.New("vol conversion failed")
-		}
-		tempCandle.Volume, err = strconv.ParseFloat(vol, 64)
-		if err != nil {
-			return kline.Item{}, err
+// Candles converts kline data to standard candles, carrying the turnover as
+// the quote volume and the buy volume as the taker buy volume
+func (s SwapKlines) Candles() []kline.Candle {
+	candles := make([]kline.Candle, len(s))
+	for x := range s {
+		candles[x] = kline.Candle{
+			Time:           s[x].Time,
+			Open:           s[x].Open,
+			High:           s[x].High,
+			Low:            s[x].Low,
+			Close:          s[x].Close,
+			Volume:         s[x].Volume,
+			QuoteVolume:    s[x].Turnover,
+			TakerBuyVolume: s[x].BuyVolume,
 		}
+	}
+	return candles
+}
+
+// GetRateLimitStatus returns the state of each endpoint rate limit bucket
+func (c *Coinbene) GetRateLimitStatus() (map[string]request.LimitStatus, error) {
+	return c.Requester.GetRateLimitStatus()
+}
+
+// GetTradingStatus returns whether Coinbene is accepting orders. Coinbene has
+// no status endpoint, so the spot pair list is used as the status source and
+// cached for DefaultTradingStatusTTL
+func (c *Coinbene) GetTradingStatus() (exchange.TradingStatus, error) {
+	return c.status.Get(exchange.DefaultTradingStatusTTL,
+		func() (exchange.TradingStatus, error) {
+			pairs, err := c.GetAllPairs()
+			if err != nil {
+				return exchange.TradingStatusUnknown, err
+			}
+			return tradingStatusFromPairs(pairs), nil
+		})
+}
 
-		ret.Candles = append(ret.Candles, tempCandle)
+// tradingStatusFromPairs maps the spot pair list to a trading status, the
+// exchange is treated as under maintenance when no pair is listed
+func tradingStatusFromPairs(pairs []PairData) exchange.TradingStatus {
+	if len(pairs) == 0 {
+		return exchange.TradingStatusMaintenance
 	}
+	return exchange.TradingStatusOpen
+}
 
-	ret.SortCandlesByTimestamp(false)
-	return ret, nil
+// GetLatestCandle returns the most recent closed candle for a pair, the
+// candle still in progress is ignored
+func (c *Coinbene) GetLatestCandle(pair currency.Pair, a asset.Item, interval kline.Interval) (kline.Candle, error) {
+	now := time.Now()
+	start := kline.TruncateInLocation(now, interval, nil).
+		Add(-interval.Duration() * kline.LatestCandleLookback)
+	item, err := c.GetHistoricCandles(pair, a, start, now, interval)
+	if err != nil {
+		return kline.Candle{}, err
+	}
+	return kline.LatestClosedCandle(item.Candles, interval, now)
 }
 
 // GetHistoricCandlesExtended returns candles between a time period for a set time interval
 func (c *Coinbene) GetHistoricCandlesExtended(pair currency.Pair, a asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
-	return c.GetHistoricCandles(pair, a, start, end, interval)
+	if err := c.ValidateKline(pair, a, interval); err != nil {
+		return kline.Item{}, err
+	}
+
+	formattedPair, err := c.FormatExchangeCurrency(pair, a)
+	if err != nil {
+		return kline.Item{}, err
+	}
+
+	candles, err := c.Features.Enabled.Kline.PaginateCandles(a, start, end, interval,
+		func(start, end time.Time) ([]kline.Candle, error) {
+			return c.fetchCandles(formattedPair.String(), a, start, end, interval)
+		})
+	if err != nil {
+		return kline.Item{}, err
+	}
+
+	return kline.Item{
+		Exchange: c.Name,
+		Pair:     pair,
+		Interval: interval,
+		Asset:    a,
+		Candles:  candles,
+	}, nil
+}
+
+// fetchCandles retrieves spot or swap candles for a single request
+func (c *Coinbene) fetchCandles(symbol string, a asset.Item, start, end time.Time, interval kline.Interval) ([]kline.Candle, error) {
+	var candles CandleResponse
+	var err error
+	if a == asset.PerpetualSwap {
+		candles, err = c.GetSwapKlines(symbol,
+			start, end,
+			c.FormatExchangeKlineInterval(interval))
+	} else {
+		candles, err = c.GetKlines(symbol,
+			start, end,
+			c.FormatExchangeKlineInterval(interval))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	klines, err := parseKlines(candles.Data)
+	if err != nil {
+		return nil, err
+	}
+	return klines.Candles(), nil
 }
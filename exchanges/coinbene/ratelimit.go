@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
-	"golang.org/x/time/rate"
 )
 
 const (
@@ -89,40 +88,40 @@ const (
 
 // RateLimit implements the request.Limiter interface
 type RateLimit struct {
-	ContractOrderbook             *rate.Limiter
-	ContractTickers               *rate.Limiter
-	ContractKline                 *rate.Limiter
-	ContractTrades                *rate.Limiter
-	ContractAccountInfo           *rate.Limiter
-	ContractPositionInfo          *rate.Limiter
-	ContractPlaceOrder            *rate.Limiter
-	ContractCancelOrder           *rate.Limiter
-	ContractGetOpenOrders         *rate.Limiter
-	ContractOpenOrdersByPage      *rate.Limiter
-	ContractGetOrderInfo          *rate.Limiter
-	ContractGetClosedOrders       *rate.Limiter
-	ContractGetClosedOrdersbyPage *rate.Limiter
-	ContractCancelMultipleOrders  *rate.Limiter
-	ContractGetOrderFills         *rate.Limiter
-	ContractGetFundingRates       *rate.Limiter
-	SpotPairs                     *rate.Limiter
-	SpotPairInfo                  *rate.Limiter
-	SpotOrderbook                 *rate.Limiter
-	SpotTickerList                *rate.Limiter
-	SpotSpecificTicker            *rate.Limiter
-	SpotMarketTrades              *rate.Limiter
+	ContractOrderbook             *request.TrackedLimiter
+	ContractTickers               *request.TrackedLimiter
+	ContractKline                 *request.TrackedLimiter
+	ContractTrades                *request.TrackedLimiter
+	ContractAccountInfo           *request.TrackedLimiter
+	ContractPositionInfo          *request.TrackedLimiter
+	ContractPlaceOrder            *request.TrackedLimiter
+	ContractCancelOrder           *request.TrackedLimiter
+	ContractGetOpenOrders         *request.TrackedLimiter
+	ContractOpenOrdersByPage      *request.TrackedLimiter
+	ContractGetOrderInfo          *request.TrackedLimiter
+	ContractGetClosedOrders       *request.TrackedLimiter
+	ContractGetClosedOrdersbyPage *request.TrackedLimiter
+	ContractCancelMultipleOrders  *request.TrackedLimiter
+	ContractGetOrderFills         *request.TrackedLimiter
+	ContractGetFundingRates       *request.TrackedLimiter
+	SpotPairs                     *request.TrackedLimiter
+	SpotPairInfo                  *request.TrackedLimiter
+	SpotOrderbook                 *request.TrackedLimiter
+	SpotTickerList                *request.TrackedLimiter
+	SpotSpecificTicker            *request.TrackedLimiter
+	SpotMarketTrades              *request.TrackedLimiter
 	// spotKline        // Not implemented yet
 	// spotExchangeRate // Not implemented yet
-	SpotAccountInfo       *rate.Limiter
-	SpotAccountAssetInfo  *rate.Limiter
-	SpotPlaceOrder        *rate.Limiter
-	SpotBatchOrder        *rate.Limiter
-	SpotQueryOpenOrders   *rate.Limiter
-	SpotQueryClosedOrders *rate.Limiter
-	SpotQuerySpecficOrder *rate.Limiter
-	SpotQueryTradeFills   *rate.Limiter
-	SpotCancelOrder       *rate.Limiter
-	SpotCancelOrdersBatch *rate.Limiter
+	SpotAccountInfo       *request.TrackedLimiter
+	SpotAccountAssetInfo  *request.TrackedLimiter
+	SpotPlaceOrder        *request.TrackedLimiter
+	SpotBatchOrder        *request.TrackedLimiter
+	SpotQueryOpenOrders   *request.TrackedLimiter
+	SpotQueryClosedOrders *request.TrackedLimiter
+	SpotQuerySpecficOrder *request.TrackedLimiter
+	SpotQueryTradeFills   *request.TrackedLimiter
+	SpotCancelOrder       *request.TrackedLimiter
+	SpotCancelOrdersBatch *request.TrackedLimiter
 }
 
 // Limit limits outbound requests
@@ -202,40 +201,78 @@ func (r *RateLimit) Limit(f request.EndpointLimit) error {
 	return nil
 }
 
+// Status returns the state of each endpoint rate limit bucket
+func (r *RateLimit) Status() map[string]request.LimitStatus {
+	return map[string]request.LimitStatus{
+		"contractOrderbook":             r.ContractOrderbook.Status(),
+		"contractTickers":               r.ContractTickers.Status(),
+		"contractKline":                 r.ContractKline.Status(),
+		"contractTrades":                r.ContractTrades.Status(),
+		"contractAccountInfo":           r.ContractAccountInfo.Status(),
+		"contractPositionInfo":          r.ContractPositionInfo.Status(),
+		"contractPlaceOrder":            r.ContractPlaceOrder.Status(),
+		"contractCancelOrder":           r.ContractCancelOrder.Status(),
+		"contractGetOpenOrders":         r.ContractGetOpenOrders.Status(),
+		"contractOpenOrdersByPage":      r.ContractOpenOrdersByPage.Status(),
+		"contractGetOrderInfo":          r.ContractGetOrderInfo.Status(),
+		"contractGetClosedOrders":       r.ContractGetClosedOrders.Status(),
+		"contractGetClosedOrdersbyPage": r.ContractGetClosedOrdersbyPage.Status(),
+		"contractCancelMultipleOrders":  r.ContractCancelMultipleOrders.Status(),
+		"contractGetOrderFills":         r.ContractGetOrderFills.Status(),
+		"contractGetFundingRates":       r.ContractGetFundingRates.Status(),
+		"spotPairs":                     r.SpotPairs.Status(),
+		"spotPairInfo":                  r.SpotPairInfo.Status(),
+		"spotOrderbook":                 r.SpotOrderbook.Status(),
+		"spotTickerList":                r.SpotTickerList.Status(),
+		"spotSpecificTicker":            r.SpotSpecificTicker.Status(),
+		"spotMarketTrades":              r.SpotMarketTrades.Status(),
+		"spotAccountInfo":               r.SpotAccountInfo.Status(),
+		"spotAccountAssetInfo":          r.SpotAccountAssetInfo.Status(),
+		"spotPlaceOrder":                r.SpotPlaceOrder.Status(),
+		"spotBatchOrder":                r.SpotBatchOrder.Status(),
+		"spotQueryOpenOrders":           r.SpotQueryOpenOrders.Status(),
+		"spotQueryClosedOrders":         r.SpotQueryClosedOrders.Status(),
+		"spotQuerySpecficOrder":         r.SpotQuerySpecficOrder.Status(),
+		"spotQueryTradeFills":           r.SpotQueryTradeFills.Status(),
+		"spotCancelOrder":               r.SpotCancelOrder.Status(),
+		"spotCancelOrdersBatch":         r.SpotCancelOrdersBatch.Status(),
+	}
+}
+
 // SetRateLimit returns the rate limit for the exchange
 func SetRateLimit() *RateLimit {
 	return &RateLimit{
-		ContractOrderbook:             request.NewRateLimit(contractRateInterval, orderbookContractReqRate),
-		ContractTickers:               request.NewRateLimit(contractRateInterval, tickersContractReqRate),
-		ContractKline:                 request.NewRateLimit(contractRateInterval, klineContractReqRate),
-		ContractTrades:                request.NewRateLimit(contractRateInterval, tradesContractReqRate),
-		ContractAccountInfo:           request.NewRateLimit(contractRateInterval, contractAccountInfoContractReqRate),
-		ContractPositionInfo:          request.NewRateLimit(contractRateInterval, positionInfoContractReqRate),
-		ContractPlaceOrder:            request.NewRateLimit(contractRateInterval, placeOrderContractReqRate),
-		ContractCancelOrder:           request.NewRateLimit(contractRateInterval, cancelOrderContractReqRate),
-		ContractGetOpenOrders:         request.NewRateLimit(contractRateInterval, getOpenOrdersContractReqRate),
-		ContractOpenOrdersByPage:      request.NewRateLimit(contractRateInterval, openOrdersByPageContractReqRate),
-		ContractGetOrderInfo:          request.NewRateLimit(contractRateInterval, getOrderInfoContractReqRate),
-		ContractGetClosedOrders:       request.NewRateLimit(contractRateInterval, getClosedOrdersContractReqRate),
-		ContractGetClosedOrdersbyPage: request.NewRateLimit(contractRateInterval, getClosedOrdersbyPageContractReqRate),
-		ContractCancelMultipleOrders:  request.NewRateLimit(contractRateInterval, cancelMultipleOrdersContractReqRate),
-		ContractGetOrderFills:         request.NewRateLimit(contractRateInterval, getOrderFillsContractReqRate),
-		ContractGetFundingRates:       request.NewRateLimit(contractRateInterval, getFundingRatesContractReqRate),
-		SpotPairs:                     request.NewRateLimit(spotRateInterval, getPairsSpotReqRate),
-		SpotPairInfo:                  request.NewRateLimit(spotRateInterval, getPairsInfoSpotReqRate),
-		SpotOrderbook:                 request.NewRateLimit(spotRateInterval, getOrderbookSpotReqRate),
-		SpotTickerList:                request.NewRateLimit(spotRateInterval, getTickerListSpotReqRate),
-		SpotSpecificTicker:            request.NewRateLimit(spotRateInterval, getSpecificTickerSpotReqRate),
-		SpotMarketTrades:              request.NewRateLimit(spotRateInterval, getMarketTradesSpotReqRate),
-		SpotAccountInfo:               request.NewRateLimit(spotRateInterval, getAccountInfoSpotReqRate),
-		SpotAccountAssetInfo:          request.NewRateLimit(spotRateInterval, queryAccountAssetInfoSpotReqRate),
-		SpotPlaceOrder:                request.NewRateLimit(spotRateInterval, placeOrderSpotReqRate),
-		SpotBatchOrder:                request.NewRateLimit(spotRateInterval, batchOrderSpotReqRate),
-		SpotQueryOpenOrders:           request.NewRateLimit(spotRateInterval, queryOpenOrdersSpotReqRate),
-		SpotQueryClosedOrders:         request.NewRateLimit(spotRateInterval, queryClosedOrdersSpotReqRate),
-		SpotQuerySpecficOrder:         request.NewRateLimit(spotRateInterval, querySpecficOrderSpotReqRate),
-		SpotQueryTradeFills:           request.NewRateLimit(spotRateInterval, queryTradeFillsSpotReqRate),
-		SpotCancelOrder:               request.NewRateLimit(spotRateInterval, cancelOrderSpotReqRate),
-		SpotCancelOrdersBatch:         request.NewRateLimit(spotRateInterval, cancelOrdersBatchSpotReqRate),
+		ContractOrderbook:             request.NewTrackedRateLimit(contractRateInterval, orderbookContractReqRate),
+		ContractTickers:               request.NewTrackedRateLimit(contractRateInterval, tickersContractReqRate),
+		ContractKline:                 request.NewTrackedRateLimit(contractRateInterval, klineContractReqRate),
+		ContractTrades:                request.NewTrackedRateLimit(contractRateInterval, tradesContractReqRate),
+		ContractAccountInfo:           request.NewTrackedRateLimit(contractRateInterval, contractAccountInfoContractReqRate),
+		ContractPositionInfo:          request.NewTrackedRateLimit(contractRateInterval, positionInfoContractReqRate),
+		ContractPlaceOrder:            request.NewTrackedRateLimit(contractRateInterval, placeOrderContractReqRate),
+		ContractCancelOrder:           request.NewTrackedRateLimit(contractRateInterval, cancelOrderContractReqRate),
+		ContractGetOpenOrders:         request.NewTrackedRateLimit(contractRateInterval, getOpenOrdersContractReqRate),
+		ContractOpenOrdersByPage:      request.NewTrackedRateLimit(contractRateInterval, openOrdersByPageContractReqRate),
+		ContractGetOrderInfo:          request.NewTrackedRateLimit(contractRateInterval, getOrderInfoContractReqRate),
+		ContractGetClosedOrders:       request.NewTrackedRateLimit(contractRateInterval, getClosedOrdersContractReqRate),
+		ContractGetClosedOrdersbyPage: request.NewTrackedRateLimit(contractRateInterval, getClosedOrdersbyPageContractReqRate),
+		ContractCancelMultipleOrders:  request.NewTrackedRateLimit(contractRateInterval, cancelMultipleOrdersContractReqRate),
+		ContractGetOrderFills:         request.NewTrackedRateLimit(contractRateInterval, getOrderFillsContractReqRate),
+		ContractGetFundingRates:       request.NewTrackedRateLimit(contractRateInterval, getFundingRatesContractReqRate),
+		SpotPairs:                     request.NewTrackedRateLimit(spotRateInterval, getPairsSpotReqRate),
+		SpotPairInfo:                  request.NewTrackedRateLimit(spotRateInterval, getPairsInfoSpotReqRate),
+		SpotOrderbook:                 request.NewTrackedRateLimit(spotRateInterval, getOrderbookSpotReqRate),
+		SpotTickerList:                request.NewTrackedRateLimit(spotRateInterval, getTickerListSpotReqRate),
+		SpotSpecificTicker:            request.NewTrackedRateLimit(spotRateInterval, getSpecificTickerSpotReqRate),
+		SpotMarketTrades:              request.NewTrackedRateLimit(spotRateInterval, getMarketTradesSpotReqRate),
+		SpotAccountInfo:               request.NewTrackedRateLimit(spotRateInterval, getAccountInfoSpotReqRate),
+		SpotAccountAssetInfo:          request.NewTrackedRateLimit(spotRateInterval, queryAccountAssetInfoSpotReqRate),
+		SpotPlaceOrder:                request.NewTrackedRateLimit(spotRateInterval, placeOrderSpotReqRate),
+		SpotBatchOrder:                request.NewTrackedRateLimit(spotRateInterval, batchOrderSpotReqRate),
+		SpotQueryOpenOrders:           request.NewTrackedRateLimit(spotRateInterval, queryOpenOrdersSpotReqRate),
+		SpotQueryClosedOrders:         request.NewTrackedRateLimit(spotRateInterval, queryClosedOrdersSpotReqRate),
+		SpotQuerySpecficOrder:         request.NewTrackedRateLimit(spotRateInterval, querySpecficOrderSpotReqRate),
+		SpotQueryTradeFills:           request.NewTrackedRateLimit(spotRateInterval, queryTradeFillsSpotReqRate),
+		SpotCancelOrder:               request.NewTrackedRateLimit(spotRateInterval, cancelOrderSpotReqRate),
+		SpotCancelOrdersBatch:         request.NewTrackedRateLimit(spotRateInterval, cancelOrdersBatchSpotReqRate),
 	}
 }
@@ -76,6 +76,13 @@ func (e *Base) SetHTTPClientUserAgent(ua string) {
 	e.HTTPUserAgent = ua
 }
 
+// SetHTTPSlowRequestThreshold sets the response time above which the
+// exchanges HTTP requests are logged as slow, zero disables the logging
+func (e *Base) SetHTTPSlowRequestThreshold(d time.Duration) {
+	e.checkAndInitRequester()
+	e.Requester.SetSlowRequestThreshold(d)
+}
+
 // GetHTTPClientUserAgent gets the exchanges HTTP user agent
 func (e *Base) GetHTTPClientUserAgent() string {
 	return e.HTTPUserAgent
@@ -545,9 +552,8 @@ func (e *Base) SetupDefaults(exch *config.ExchangeConfig) error {
 	e.API.AuthenticatedSupport = exch.API.AuthenticatedSupport
 	e.API.AuthenticatedWebsocketSupport = exch.API.AuthenticatedWebsocketSupport
 	if e.API.AuthenticatedSupport || e.API.AuthenticatedWebsocketSupport {
-		e.SetAPIKeys(exch.API.Credentials.Key,
-			exch.API.Credentials.Secret,
-			exch.API.Credentials.ClientID)
+		creds := exch.CredentialsWithEnvironmentOverrides()
+		e.SetAPIKeys(creds.Key, creds.Secret, creds.ClientID)
 	}
 
 	if exch.HTTPTimeout <= time.Duration(0) {
@@ -565,6 +571,7 @@ func (e *Base) SetupDefaults(exch *config.ExchangeConfig) error {
 
 	e.HTTPDebugging = exch.HTTPDebugging
 	e.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+	e.SetHTTPSlowRequestThreshold(exch.HTTPSlowRequestThreshold)
 	e.SetCurrencyPairFormat()
 
 	err := e.SetConfigPairs()
@@ -1013,6 +1020,45 @@ func (e *Base) SupportsWebsocket() bool {
 	return e.Features.Supports.Websocket
 }
 
+// SupportsWebsocketOrderbook returns whether orderbooks can be streamed over
+// the exchange websocket
+func (e *Base) SupportsWebsocketOrderbook() bool {
+	return e.Features.Supports.Websocket &&
+		e.Features.Supports.WebsocketCapabilities.OrderbookFetching
+}
+
+// SupportsWebsocketTicker returns whether tickers can be streamed over the
+// exchange websocket
+func (e *Base) SupportsWebsocketTicker() bool {
+	return e.Features.Supports.Websocket &&
+		e.Features.Supports.WebsocketCapabilities.TickerFetching
+}
+
+// SupportsWebsocketKline returns whether candles can be streamed over the
+// exchange websocket
+func (e *Base) SupportsWebsocketKline() bool {
+	return e.Features.Supports.Websocket &&
+		e.Features.Supports.WebsocketCapabilities.KlineFetching
+}
+
+// SupportsRESTOrderbook returns whether orderbooks can be fetched over REST
+func (e *Base) SupportsRESTOrderbook() bool {
+	return e.Features.Supports.REST &&
+		e.Features.Supports.RESTCapabilities.OrderbookFetching
+}
+
+// SupportsRESTTicker returns whether tickers can be fetched over REST
+func (e *Base) SupportsRESTTicker() bool {
+	return e.Features.Supports.REST &&
+		e.Features.Supports.RESTCapabilities.TickerFetching
+}
+
+// SupportsRESTKline returns whether candles can be fetched over REST
+func (e *Base) SupportsRESTKline() bool {
+	return e.Features.Supports.REST &&
+		e.Features.Supports.RESTCapabilities.KlineFetching
+}
+
 // IsWebsocketEnabled returns whether or not the exchange has its
 // websocket client enabled
 func (e *Base) IsWebsocketEnabled() bool {
@@ -1097,3 +1143,20 @@ func (e *Base) ValidateKline(pair currency.Pair, a asset.Item, interval kline.In
 
 	return nil
 }
+
+// Get returns the cached trading status, calling fetch to refresh it once the
+// cached value is older than ttl. Failed fetches are not cached
+func (t *TradingStatusCache) Get(ttl time.Duration, fetch func() (TradingStatus, error)) (TradingStatus, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.status != "" && time.Now().Before(t.expires) {
+		return t.status, nil
+	}
+	status, err := fetch()
+	if err != nil {
+		return TradingStatusUnknown, err
+	}
+	t.status = status
+	t.expires = time.Now().Add(ttl)
+	return status, nil
+}
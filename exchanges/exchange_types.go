@@ -1,6 +1,7 @@
 package exchange
 
 import (
+	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/config"
@@ -139,6 +140,29 @@ type FundHistory struct {
 	BankFrom          string
 }
 
+// TradingStatus defines whether an exchange is currently accepting orders
+type TradingStatus string
+
+// Exchange trading statuses
+const (
+	TradingStatusUnknown     TradingStatus = "unknown"
+	TradingStatusOpen        TradingStatus = "open"
+	TradingStatusMaintenance TradingStatus = "maintenance"
+	TradingStatusHalted      TradingStatus = "halted"
+
+	// DefaultTradingStatusTTL is how long a fetched trading status is reused
+	// before the exchange is queried again
+	DefaultTradingStatusTTL = time.Second * 30
+)
+
+// TradingStatusCache stores a fetched trading status for a short period so
+// frequent order flow checks do not hammer an exchange status endpoint
+type TradingStatusCache struct {
+	m       sync.Mutex
+	status  TradingStatus
+	expires time.Time
+}
+
 // Features stores the supported and enabled features
 // for the exchange
 type Features struct {
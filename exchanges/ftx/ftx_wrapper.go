@@ -981,7 +981,7 @@ func (f *FTX) GetHistoricCandlesExtended(p currency.Pair, a asset.Item, start, e
 		Interval: interval,
 	}
 
-	dates := kline.CalcDateRanges(start, end, interval, f.Features.Enabled.Kline.ResultLimit)
+	dates := kline.CalcDateRanges(start, end, interval, f.Features.Enabled.Kline.ResultLimit, nil)
 
 	formattedPair, err := f.FormatExchangeCurrency(p, a)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
@@ -63,6 +64,9 @@ type IBotExchange interface {
 	GetHTTPClientUserAgent() string
 	SetClientProxyAddress(addr string) error
 	SupportsREST() bool
+	SupportsRESTOrderbook() bool
+	SupportsRESTTicker() bool
+	SupportsRESTKline() bool
 	GetSubscriptions() ([]stream.ChannelSubscription, error)
 	GetDefaultConfig() (*config.ExchangeConfig, error)
 	GetBase() *Base
@@ -77,6 +81,9 @@ type IBotExchange interface {
 	GetWebsocket() (*stream.Websocket, error)
 	IsWebsocketEnabled() bool
 	SupportsWebsocket() bool
+	SupportsWebsocketOrderbook() bool
+	SupportsWebsocketTicker() bool
+	SupportsWebsocketKline() bool
 	SubscribeToWebsocketChannels(channels []stream.ChannelSubscription) error
 	UnsubscribeToWebsocketChannels(channels []stream.ChannelSubscription) error
 	// FlushWebsocketChannels checks and flushes subscriptions if there is a
@@ -84,3 +91,15 @@ type IBotExchange interface {
 	FlushWebsocketChannels() error
 	AuthenticateWebsocket() error
 }
+
+// RateLimitStatusReporter is implemented by exchanges which can report the
+// state of their rate limit buckets
+type RateLimitStatusReporter interface {
+	GetRateLimitStatus() (map[string]request.LimitStatus, error)
+}
+
+// TradingStatusFetcher is implemented by exchanges which can report whether
+// they are open for trading or in maintenance
+type TradingStatusFetcher interface {
+	GetTradingStatus() (TradingStatus, error)
+}
@@ -12,18 +12,65 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
+// NewItem returns a validated kline item to which candles can be added
+func NewItem(exchange string, pair currency.Pair, a asset.Item, interval Interval) (*Item, error) {
+	if exchange == "" {
+		return nil, errExchangeNameUnset
+	}
+	if pair.IsEmpty() {
+		return nil, errPairUnset
+	}
+	if !asset.IsValid(a) {
+		return nil, fmt.Errorf("%s %w", a, errAssetInvalid)
+	}
+	if interval <= 0 {
+		return nil, errIntervalUnset
+	}
+	return &Item{
+		Exchange: exchange,
+		Pair:     pair,
+		Asset:    a,
+		Interval: interval,
+	}, nil
+}
+
+// AddCandle inserts a candle keeping candles sorted by ascending time, a
+// candle sharing a timestamp with an existing candle is placed after it
+func (k *Item) AddCandle(c Candle) {
+	i := sort.Search(len(k.Candles), func(i int) bool {
+		return k.Candles[i].Time.After(c.Time)
+	})
+	k.Candles = append(k.Candles, Candle{})
+	copy(k.Candles[i+1:], k.Candles[i:])
+	k.Candles[i] = c
+}
+
+// AddCandles appends candles and sorts all candles by ascending time
+func (k *Item) AddCandles(c []Candle) {
+	k.Candles = append(k.Candles, c...)
+	k.Sort()
+}
+
 // CreateKline creates candles out of trade history data for a set time interval
 func CreateKline(trades []order.TradeHistory, interval Interval, p currency.Pair, a asset.Item, exchange string) (Item, error) {
+	return CreateKlineInLocation(trades, interval, p, a, exchange, nil)
+}
+
+// CreateKlineInLocation creates candles out of trade history data with
+// candle buckets aligned to the interval boundary in the supplied location,
+// a nil location defaults to UTC
+func CreateKlineInLocation(trades []order.TradeHistory, interval Interval, p currency.Pair, a asset.Item, exchange string, loc *time.Location) (Item, error) {
 	if interval.Duration() < time.Minute {
 		return Item{}, fmt.Errorf("invalid time interval: [%s]", interval)
 	}
 
+	trades = removeDuplicateTrades(trades)
 	err := validateData(trades)
 	if err != nil {
 		return Item{}, err
 	}
 
-	timeIntervalStart := trades[0].Timestamp.Truncate(interval.Duration())
+	timeIntervalStart := TruncateInLocation(trades[0].Timestamp, interval, loc)
 	timeIntervalEnd := trades[len(trades)-1].Timestamp
 
 	// Adds time interval buffer zones
@@ -98,6 +145,7 @@ func CreateKline(trades []order.TradeHistory, interval Interval, p currency.Pair
 				newCandle.Low = timeIntervalCache[x][y].Price
 			}
 			newCandle.Volume += timeIntervalCache[x][y].Amount
+			newCandle.QuoteVolume += timeIntervalCache[x][y].Amount * timeIntervalCache[x][y].Price
 		}
 		candles.Candles = append(candles.Candles, newCandle)
 	}
@@ -132,6 +180,24 @@ func validateData(trades []order.TradeHistory) error {
 	return nil
 }
 
+// removeDuplicateTrades drops trades which share a trade ID with an earlier
+// trade, which occurs when paginated fetches overlap. The first occurrence is
+// kept and trades without an ID are left untouched
+func removeDuplicateTrades(trades []order.TradeHistory) []order.TradeHistory {
+	seen := make(map[string]struct{}, len(trades))
+	resp := make([]order.TradeHistory, 0, len(trades))
+	for i := range trades {
+		if trades[i].TID != "" {
+			if _, ok := seen[trades[i].TID]; ok {
+				continue
+			}
+			seen[trades[i].TID] = struct{}{}
+		}
+		resp = append(resp, trades[i])
+	}
+	return resp
+}
+
 // String returns numeric string
 func (i Interval) String() string {
 	return i.Duration().String()
@@ -159,11 +225,29 @@ func (i Interval) Short() string {
 	return s
 }
 
+// IsMultipleOf returns whether the interval is a whole multiple of other,
+// e.g. 5m is a multiple of 1m, intervals are multiples of themselves
+func (i Interval) IsMultipleOf(other Interval) bool {
+	_, exact := i.Ratio(other)
+	return exact
+}
+
+// Ratio returns how many whole other intervals fit within the interval and
+// whether it divides exactly, e.g. 5m.Ratio(2m) returns 2, false
+func (i Interval) Ratio(other Interval) (int, bool) {
+	if i <= 0 || other <= 0 {
+		return 0, false
+	}
+	return int(i / other), i%other == 0
+}
+
 // durationToWord returns english version of interval
 func durationToWord(in Interval) string {
 	switch in {
 	case FifteenSecond:
 		return "fifteensecond"
+	case ThirtySecond:
+		return "thirtysecond"
 	case OneMin:
 		return "onemin"
 	case ThreeMin:
@@ -212,6 +296,8 @@ func TotalCandlesPerInterval(start, end time.Time, interval Interval) (out uint3
 	switch interval {
 	case FifteenSecond:
 		out = uint32(end.Sub(start).Seconds() / 15)
+	case ThirtySecond:
+		out = uint32(end.Sub(start).Seconds() / 30)
 	case OneMin:
 		out = uint32(end.Sub(start).Minutes())
 	case ThreeMin:
@@ -254,8 +340,67 @@ func TotalCandlesPerInterval(start, end time.Time, interval Interval) (out uint3
 	return out
 }
 
-// CalcDateRanges returns slice of start/end times based on start & end date
-func CalcDateRanges(start, end time.Time, interval Interval, limit uint32) (out []DateRange) {
+// GetResultLimit returns the max amount of candles the exchange returns per
+// request for the supplied asset, falling back to ResultLimit when an asset
+// specific limit is not set
+func (e *ExchangeCapabilitiesEnabled) GetResultLimit(a asset.Item) uint32 {
+	if limit, ok := e.ResultLimits[a]; ok && limit > 0 {
+		return limit
+	}
+	return e.ResultLimit
+}
+
+// PaginateCandles fetches candles for an asset between start and end, splitting
+// the request into date ranges sized by the configured result limit so callers
+// do not need to know the limit. The combined candles are returned sorted with
+// duplicates at range boundaries removed. When no limit is configured a single
+// request is made
+func (e *ExchangeCapabilitiesEnabled) PaginateCandles(a asset.Item, start, end time.Time, interval Interval, fetch func(start, end time.Time) ([]Candle, error)) ([]Candle, error) {
+	limit := e.GetResultLimit(a)
+	if limit == 0 {
+		return fetch(start, end)
+	}
+	var combined Item
+	dates := CalcDateRanges(start, end, interval, limit, nil)
+	for x := range dates {
+		candles, err := fetch(dates[x].Start, dates[x].End)
+		if err != nil {
+			return nil, err
+		}
+		combined.Candles = append(combined.Candles, candles...)
+	}
+	combined.RemoveDuplicates()
+	return combined.Candles, nil
+}
+
+// TruncateInLocation rounds a time down to the start of its interval bucket
+// using the wall clock of the supplied location, so daily and larger candles
+// align to that location's day boundary. A nil location defaults to UTC
+func TruncateInLocation(t time.Time, interval Interval, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if interval <= 0 {
+		return t.In(loc)
+	}
+	t = t.In(loc)
+	wall := time.Date(t.Year(), t.Month(), t.Day(),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC).
+		Truncate(interval.Duration())
+	return time.Date(wall.Year(), wall.Month(), wall.Day(),
+		wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
+
+// CalcDateRanges returns slice of start/end times based on start & end date.
+// The start date is aligned to the interval boundary in the supplied location
+// so daily and larger ranges begin on that location's day boundary. A nil
+// location defaults to UTC
+func CalcDateRanges(start, end time.Time, interval Interval, limit uint32, loc *time.Location) (out []DateRange) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	start = TruncateInLocation(start, interval, loc)
+	end = end.In(loc)
 	total := TotalCandlesPerInterval(start, end, interval)
 	if total < limit {
 		return []DateRange{{
@@ -290,6 +435,240 @@ func CalcDateRanges(start, end time.Time, interval Interval, limit uint32) (out
 	return out
 }
 
+// ConvertToNewInterval aggregates candles into a larger interval which must be
+// a whole multiple of the existing interval e.g. 1m candles into 15m candles
+func (k *Item) ConvertToNewInterval(newInterval Interval) (Item, error) {
+	return k.ConvertToNewIntervalInLocation(newInterval, nil)
+}
+
+// ConvertToNewIntervalInLocation aggregates candles into a larger interval
+// with buckets aligned to the interval boundary in the supplied location, e.g.
+// daily candles starting at midnight exchange time. A nil location defaults to
+// UTC
+func (k *Item) ConvertToNewIntervalInLocation(newInterval Interval, loc *time.Location) (Item, error) {
+	if k.Interval <= 0 {
+		return Item{}, errIntervalUnset
+	}
+	if newInterval <= k.Interval {
+		return Item{}, &ErrorKline{
+			Asset:    k.Asset,
+			Pair:     k.Pair,
+			Interval: newInterval,
+			Err: fmt.Errorf("cannot convert candles from %s to %s, new interval must be larger",
+				k.Interval,
+				newInterval),
+		}
+	}
+	if !newInterval.IsMultipleOf(k.Interval) {
+		return Item{}, &ErrorKline{
+			Asset:    k.Asset,
+			Pair:     k.Pair,
+			Interval: newInterval,
+			Err: fmt.Errorf("cannot convert candles from %s to %s, new interval must be a multiple of the existing interval",
+				k.Interval,
+				newInterval),
+		}
+	}
+	if len(k.Candles) == 0 {
+		return Item{}, &ErrNoCandles{Exchange: k.Exchange, Pair: k.Pair, Asset: k.Asset}
+	}
+
+	ret := Item{
+		Exchange: k.Exchange,
+		Pair:     k.Pair,
+		Asset:    k.Asset,
+		Interval: newInterval,
+	}
+
+	sorted := Item{Candles: make([]Candle, len(k.Candles))}
+	copy(sorted.Candles, k.Candles)
+	sorted.RemoveDuplicates()
+	candles := sorted.Candles
+
+	for x := range candles {
+		bucket := TruncateInLocation(candles[x].Time, newInterval, loc)
+		last := len(ret.Candles) - 1
+		if last < 0 || !ret.Candles[last].Time.Equal(bucket) {
+			ret.Candles = append(ret.Candles, Candle{
+				Time:           bucket,
+				Open:           candles[x].Open,
+				High:           candles[x].High,
+				Low:            candles[x].Low,
+				Close:          candles[x].Close,
+				Volume:         candles[x].Volume,
+				QuoteVolume:    candles[x].QuoteVolume,
+				TakerBuyVolume: candles[x].TakerBuyVolume,
+			})
+			continue
+		}
+		if candles[x].High > ret.Candles[last].High {
+			ret.Candles[last].High = candles[x].High
+		}
+		if candles[x].Low < ret.Candles[last].Low {
+			ret.Candles[last].Low = candles[x].Low
+		}
+		ret.Candles[last].Close = candles[x].Close
+		ret.Candles[last].Volume += candles[x].Volume
+		ret.Candles[last].QuoteVolume += candles[x].QuoteVolume
+		ret.Candles[last].TakerBuyVolume += candles[x].TakerBuyVolume
+	}
+	return ret, nil
+}
+
+// Sort orders candles by ascending time, candles sharing a timestamp keep
+// their existing order
+func (k *Item) Sort() {
+	sort.SliceStable(k.Candles, func(i, j int) bool {
+		return k.Candles[i].Time.Before(k.Candles[j].Time)
+	})
+}
+
+// RemoveDuplicates sorts candles by ascending time and removes candles sharing
+// a timestamp, keeping the last one received
+func (k *Item) RemoveDuplicates() {
+	k.Sort()
+	if len(k.Candles) < 2 {
+		return
+	}
+	deduped := k.Candles[:1]
+	for x := 1; x < len(k.Candles); x++ {
+		if k.Candles[x].Time.Equal(deduped[len(deduped)-1].Time) {
+			deduped[len(deduped)-1] = k.Candles[x]
+			continue
+		}
+		deduped = append(deduped, k.Candles[x])
+	}
+	k.Candles = deduped
+}
+
+// Validate checks the item holds candles in ascending time order spaced by
+// its interval. Failures are returned as *ErrNoCandles, *ErrUnsortedCandles
+// or *ErrUnevenIntervals so callers can distinguish them with errors.As
+func (k *Item) Validate() error {
+	if len(k.Candles) == 0 {
+		return &ErrNoCandles{Exchange: k.Exchange, Pair: k.Pair, Asset: k.Asset}
+	}
+	for x := 1; x < len(k.Candles); x++ {
+		if k.Candles[x].Time.Before(k.Candles[x-1].Time) {
+			return &ErrUnsortedCandles{
+				Index:    x,
+				Previous: k.Candles[x-1].Time,
+				Current:  k.Candles[x].Time,
+			}
+		}
+	}
+	return k.ValidateInterval()
+}
+
+// ValidateInterval checks candles are spaced by the item's interval. Gaps of
+// whole intervals are allowed, but at least one pair of candles must be
+// exactly one interval apart so coarser data labelled with a finer interval
+// is rejected. Mismatched spacing is returned as *ErrUnevenIntervals
+func (k *Item) ValidateInterval() error {
+	if k.Interval <= 0 {
+		return errIntervalUnset
+	}
+	if len(k.Candles) < 2 {
+		return nil
+	}
+	times := make([]time.Time, len(k.Candles))
+	for x := range k.Candles {
+		times[x] = k.Candles[x].Time
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	interval := k.Interval.Duration()
+	var closest *ErrUnevenIntervals
+	for x := 1; x < len(times); x++ {
+		diff := times[x].Sub(times[x-1])
+		if diff == 0 {
+			continue
+		}
+		if !Interval(diff).IsMultipleOf(k.Interval) {
+			return &ErrUnevenIntervals{
+				Interval: k.Interval,
+				Previous: times[x-1],
+				Current:  times[x],
+			}
+		}
+		if closest == nil || diff < closest.Current.Sub(closest.Previous) {
+			closest = &ErrUnevenIntervals{
+				Interval: k.Interval,
+				Previous: times[x-1],
+				Current:  times[x],
+			}
+		}
+	}
+	if closest != nil && closest.Current.Sub(closest.Previous) != interval {
+		return closest
+	}
+	return nil
+}
+
+// LatestClosedCandle returns the most recent candle which had closed by now,
+// ignoring the in progress candle
+func LatestClosedCandle(candles []Candle, interval Interval, now time.Time) (Candle, error) {
+	var latest Candle
+	var found bool
+	for x := range candles {
+		if candles[x].Time.Add(interval.Duration()).After(now) {
+			continue
+		}
+		if !found || candles[x].Time.After(latest.Time) {
+			latest = candles[x]
+			found = true
+		}
+	}
+	if !found {
+		return Candle{}, ErrNoClosedCandle
+	}
+	return latest, nil
+}
+
+// Diff returns candles from other which are missing from or differ to the
+// receiver by timestamp, along with receiver candles missing from other. This
+// can be used to detect exchange restatements before re-storing candles
+func (k *Item) Diff(other *Item) []Candle {
+	if other == nil {
+		diff := make([]Candle, len(k.Candles))
+		copy(diff, k.Candles)
+		return diff
+	}
+	existing := make(map[int64]Candle, len(k.Candles))
+	for x := range k.Candles {
+		existing[k.Candles[x].Time.UnixNano()] = k.Candles[x]
+	}
+	var diff []Candle
+	for x := range other.Candles {
+		ts := other.Candles[x].Time.UnixNano()
+		c, ok := existing[ts]
+		if !ok || !candlesEqual(c, other.Candles[x]) {
+			diff = append(diff, other.Candles[x])
+		}
+		delete(existing, ts)
+	}
+	for x := range k.Candles {
+		if _, ok := existing[k.Candles[x].Time.UnixNano()]; ok {
+			diff = append(diff, k.Candles[x])
+		}
+	}
+	sort.SliceStable(diff, func(i, j int) bool {
+		return diff[i].Time.Before(diff[j].Time)
+	})
+	return diff
+}
+
+func candlesEqual(a, b Candle) bool {
+	return a.Time.Equal(b.Time) &&
+		a.Open == b.Open &&
+		a.High == b.High &&
+		a.Low == b.Low &&
+		a.Close == b.Close &&
+		a.Volume == b.Volume &&
+		a.QuoteVolume == b.QuoteVolume &&
+		a.TakerBuyVolume == b.TakerBuyVolume
+}
+
 // SortCandlesByTimestamp sorts candles by timestamp
 func (k *Item) SortCandlesByTimestamp(desc bool) {
 	sort.Slice(k.Candles, func(i, j int) bool {
@@ -300,6 +679,41 @@ func (k *Item) SortCandlesByTimestamp(desc bool) {
 	})
 }
 
+// GetClosePrices returns the close price of each candle
+func (k *Item) GetClosePrices() []float64 {
+	closes := make([]float64, len(k.Candles))
+	for x := range k.Candles {
+		closes[x] = k.Candles[x].Close
+	}
+	return closes
+}
+
+// GetVolumes returns the volume of each candle
+func (k *Item) GetVolumes() []float64 {
+	volumes := make([]float64, len(k.Candles))
+	for x := range k.Candles {
+		volumes[x] = k.Candles[x].Volume
+	}
+	return volumes
+}
+
+// GetOHLCV returns the open, high, low, close and volume series of the candles
+func (k *Item) GetOHLCV() (o, h, l, c, v []float64) {
+	o = make([]float64, len(k.Candles))
+	h = make([]float64, len(k.Candles))
+	l = make([]float64, len(k.Candles))
+	c = make([]float64, len(k.Candles))
+	v = make([]float64, len(k.Candles))
+	for x := range k.Candles {
+		o[x] = k.Candles[x].Open
+		h[x] = k.Candles[x].High
+		l[x] = k.Candles[x].Low
+		c[x] = k.Candles[x].Close
+		v[x] = k.Candles[x].Volume
+	}
+	return
+}
+
 // FormatDates converts all date to UTC time
 func (k *Item) FormatDates() {
 	for x := range k.Candles {
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strconv"
+	"sync/atomic"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
@@ -33,19 +36,43 @@ func LoadFromDatabase(exchange string, pair currency.Pair, a asset.Item, interva
 
 	for x := range retCandle.Candles {
 		ret.Candles = append(ret.Candles, Candle{
-			Time:   retCandle.Candles[x].Timestamp,
-			Open:   retCandle.Candles[x].Open,
-			High:   retCandle.Candles[x].High,
-			Low:    retCandle.Candles[x].Low,
-			Close:  retCandle.Candles[x].Close,
-			Volume: retCandle.Candles[x].Volume,
+			Time:        retCandle.Candles[x].Timestamp,
+			Open:        retCandle.Candles[x].Open,
+			High:        retCandle.Candles[x].High,
+			Low:         retCandle.Candles[x].Low,
+			Close:       retCandle.Candles[x].Close,
+			Volume:      retCandle.Candles[x].Volume,
+			QuoteVolume: retCandle.Candles[x].QuoteVolume,
 		})
 	}
 	return ret, nil
 }
 
-// StoreInDatabase returns Item from database seeded data
+// StoreInDatabase stores the item's candles in the database in batches of
+// DefaultStoreBatchSize candles. The item's candles are sorted and deduped in
+// place
 func StoreInDatabase(in *Item) (uint64, error) {
+	return StoreInDatabaseBatched(in, DefaultStoreBatchSize, nil)
+}
+
+// StoreInDatabaseVerified validates the candles are spaced by the item's
+// interval before storing them, so a mislabelled item is rejected rather than
+// corrupting the store
+func StoreInDatabaseVerified(in *Item) (uint64, error) {
+	err := in.ValidateInterval()
+	if err != nil {
+		return 0, err
+	}
+	return StoreInDatabase(in)
+}
+
+// StoreInDatabaseBatched stores the item's candles in the database, writing
+// at most batchSize candles per transaction so large backfills do not build
+// a single massive insert. Progress, if set, is called after each batch with
+// the running total of candles stored. A batch size of zero or less stores
+// all candles in a single batch. The item's candles are sorted by time and
+// deduped in place before storing, keeping the last candle of a timestamp
+func StoreInDatabaseBatched(in *Item, batchSize int, progress func(stored uint64, total int)) (uint64, error) {
 	if in.Exchange == "" {
 		return 0, errors.New("name cannot be blank")
 	}
@@ -62,30 +89,86 @@ func StoreInDatabase(in *Item) (uint64, error) {
 		return 0, errors.New("candle data is empty")
 	}
 
+	// Deduped in place as copying the candles would double peak memory on
+	// large backfills
+	in.RemoveDuplicates()
+
 	exchangeUUID, err := exchange.UUIDByName(in.Exchange)
 	if err != nil {
 		return 0, err
 	}
 
-	databaseCandles := candle.Item{
-		ExchangeID: exchangeUUID.String(),
-		Base:       in.Pair.Base.Upper().String(),
-		Quote:      in.Pair.Quote.Upper().String(),
-		Interval:   int64(in.Interval.Duration().Seconds()),
-		Asset:      in.Asset.String(),
+	if batchSize <= 0 || batchSize > len(in.Candles) {
+		batchSize = len(in.Candles)
 	}
 
-	for x := range in.Candles {
-		databaseCandles.Candles = append(databaseCandles.Candles, candle.Candle{
-			Timestamp: in.Candles[x].Time,
-			Open:      in.Candles[x].Open,
-			High:      in.Candles[x].High,
-			Low:       in.Candles[x].Low,
-			Close:     in.Candles[x].Close,
-			Volume:    in.Candles[x].Volume,
-		})
+	precision := int(atomic.LoadInt32(&storePrecision))
+	var stored uint64
+	for start := 0; start < len(in.Candles); start += batchSize {
+		end := start + batchSize
+		if end > len(in.Candles) {
+			end = len(in.Candles)
+		}
+		databaseCandles := candle.Item{
+			ExchangeID: exchangeUUID.String(),
+			Base:       in.Pair.Base.Upper().String(),
+			Quote:      in.Pair.Quote.Upper().String(),
+			Interval:   int64(in.Interval.Duration().Seconds()),
+			Asset:      in.Asset.String(),
+			Candles:    make([]candle.Candle, 0, end-start),
+		}
+		for x := start; x < end; x++ {
+			databaseCandles.Candles = append(databaseCandles.Candles,
+				databaseCandle(&in.Candles[x], precision))
+		}
+		inserted, err := candle.Insert(&databaseCandles)
+		if err != nil {
+			return stored, err
+		}
+		stored += inserted
+		if progress != nil {
+			progress(stored, len(in.Candles))
+		}
 	}
-	return candle.Insert(&databaseCandles)
+	return stored, nil
+}
+
+// SetStorePrecision sets the number of decimals candle values are rounded to
+// before being stored so stored candles are canonical and compare stably. A
+// negative value, the default, stores values unrounded
+func SetStorePrecision(decimals int) {
+	if decimals < 0 {
+		decimals = -1
+	}
+	atomic.StoreInt32(&storePrecision, int32(decimals))
+}
+
+// databaseCandle converts a candle for storage, rounding its values to
+// precision decimals unless precision is negative
+func databaseCandle(c *Candle, precision int) candle.Candle {
+	out := candle.Candle{
+		Timestamp:   c.Time,
+		Open:        c.Open,
+		High:        c.High,
+		Low:         c.Low,
+		Close:       c.Close,
+		Volume:      c.Volume,
+		QuoteVolume: c.QuoteVolume,
+	}
+	if precision < 0 {
+		return out
+	}
+	pow := math.Pow(10, float64(precision))
+	round := func(v float64) float64 {
+		return math.Round(v*pow) / pow
+	}
+	out.Open = round(out.Open)
+	out.High = round(out.High)
+	out.Low = round(out.Low)
+	out.Close = round(out.Close)
+	out.Volume = round(out.Volume)
+	out.QuoteVolume = round(out.QuoteVolume)
+	return out
 }
 
 // LoadFromGCTScriptCSV loads kline data from a CSV file
@@ -153,3 +236,103 @@ func LoadFromGCTScriptCSV(file string) (out []Candle, errRet error) {
 	}
 	return out, err
 }
+
+// csvHeader is the column layout used when exporting candles to CSV, the
+// quote volume column is only written when a candle carries quote volume
+var csvHeader = []string{"time", "open", "high", "low", "close", "volume", "quote_volume"}
+
+// ToCSV writes the item's candles as CSV with a header row followed by one
+// row per candle. Times are formatted as RFC3339
+func (k *Item) ToCSV(w io.Writer) error {
+	var withQuoteVolume bool
+	for x := range k.Candles {
+		if k.Candles[x].QuoteVolume != 0 {
+			withQuoteVolume = true
+			break
+		}
+	}
+	columns := len(csvHeader)
+	if !withQuoteVolume {
+		columns--
+	}
+
+	csvWriter := csv.NewWriter(w)
+	err := csvWriter.Write(csvHeader[:columns])
+	if err != nil {
+		return err
+	}
+	formatFloat := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	for x := range k.Candles {
+		row := []string{
+			k.Candles[x].Time.UTC().Format(time.RFC3339),
+			formatFloat(k.Candles[x].Open),
+			formatFloat(k.Candles[x].High),
+			formatFloat(k.Candles[x].Low),
+			formatFloat(k.Candles[x].Close),
+			formatFloat(k.Candles[x].Volume),
+		}
+		if withQuoteVolume {
+			row = append(row, formatFloat(k.Candles[x].QuoteVolume))
+		}
+		err = csvWriter.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// LoadCandlesFromCSV parses candles in the column layout written by ToCSV
+// into an item. The header row and quote volume column are optional, times
+// must be RFC3339 and strictly ascending
+func LoadCandlesFromCSV(r io.Reader, exchange string, pair currency.Pair, a asset.Item, interval Interval) (*Item, error) {
+	item, err := NewItem(exchange, pair, a, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	for row := 1; ; row++ {
+		record, err := csvReader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if row == 1 && record[0] == csvHeader[0] {
+			continue
+		}
+		if len(record) != len(csvHeader) && len(record) != len(csvHeader)-1 {
+			return nil, fmt.Errorf("%w: row %d has %d columns",
+				errCSVColumnCount,
+				row,
+				len(record))
+		}
+
+		var c Candle
+		c.Time, err = time.Parse(time.RFC3339, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if len(item.Candles) > 0 && !c.Time.After(item.Candles[len(item.Candles)-1].Time) {
+			return nil, fmt.Errorf("%w: row %d time %s",
+				errCSVTimeOrder,
+				row,
+				record[0])
+		}
+		values := []*float64{&c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &c.QuoteVolume}
+		for x := 1; x < len(record); x++ {
+			*values[x-1], err = convert.FloatFromField(csvHeader[x], record[x])
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", row, err)
+			}
+		}
+		item.Candles = append(item.Candles, c)
+	}
+	return item, nil
+}
@@ -7,9 +7,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
@@ -88,6 +90,37 @@ func TestValidateData(t *testing.T) {
 	}
 }
 
+func TestCreateKlineDuplicateTrades(t *testing.T) {
+	t.Parallel()
+	tn := time.Date(2020, 10, 17, 10, 0, 0, 0, time.UTC)
+	trades := []order.TradeHistory{
+		{Timestamp: tn.Add(time.Second), TID: "1", Amount: 1, Price: 1000},
+		{Timestamp: tn.Add(2 * time.Second), TID: "2", Amount: 2, Price: 1001},
+		{Timestamp: tn.Add(3 * time.Second), TID: "3", Amount: 3, Price: 1002},
+		// overlapping page
+		{Timestamp: tn.Add(2 * time.Second), TID: "2", Amount: 5, Price: 999},
+		{Timestamp: tn.Add(3 * time.Second), TID: "3", Amount: 3, Price: 1002},
+		{Timestamp: tn.Add(4 * time.Second), TID: "4", Amount: 4, Price: 1003},
+	}
+	c, err := CreateKline(trades,
+		OneMin,
+		currency.NewPair(currency.BTC, currency.USD),
+		asset.Spot,
+		"Binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Candles) != 1 {
+		t.Fatalf("expected %v, received %v", 1, len(c.Candles))
+	}
+	if c.Candles[0].Volume != 10 {
+		t.Errorf("expected %v, received %v", 10, c.Candles[0].Volume)
+	}
+	if c.Candles[0].Low != 1000 {
+		t.Errorf("expected first occurrence to be kept, received low %v", c.Candles[0].Low)
+	}
+}
+
 func TestCreateKline(t *testing.T) {
 	c, err := CreateKline(nil,
 		OneMin,
@@ -149,6 +182,12 @@ func TestKlineShort(t *testing.T) {
 	if OneDay.Short() != "24h" {
 		t.Fatalf("unexpected result: %v", OneDay.Short())
 	}
+	if FifteenSecond.Short() != "15s" {
+		t.Fatalf("unexpected result: %v", FifteenSecond.Short())
+	}
+	if ThirtySecond.Short() != "30s" {
+		t.Fatalf("unexpected result: %v", ThirtySecond.Short())
+	}
 }
 
 func TestDurationToWord(t *testing.T) {
@@ -160,6 +199,10 @@ func TestDurationToWord(t *testing.T) {
 			"FifteenSecond",
 			FifteenSecond,
 		},
+		{
+			"ThirtySecond",
+			ThirtySecond,
+		},
 		{
 			"OneMin",
 			OneMin,
@@ -290,6 +333,11 @@ func TestTotalCandlesPerInterval(t *testing.T) {
 			FifteenSecond,
 			2102400,
 		},
+		{
+			"ThirtySecond",
+			ThirtySecond,
+			1051200,
+		},
 		{
 			"OneMin",
 			OneMin,
@@ -401,18 +449,142 @@ func TestCalcDateRanges(t *testing.T) {
 	start := time.Unix(1546300800, 0)
 	end := time.Unix(1577836799, 0)
 
-	v := CalcDateRanges(start, end, OneMin, 300)
+	v := CalcDateRanges(start, end, OneMin, 300, nil)
 
 	if v[0].Start.Unix() != time.Unix(1546300800, 0).Unix() {
 		t.Fatalf("unexpected result received %v", v[0].Start.Unix())
 	}
 
-	v = CalcDateRanges(time.Now(), time.Now().AddDate(0, 0, 1), OneDay, 100)
+	v = CalcDateRanges(time.Now(), time.Now().AddDate(0, 0, 1), OneDay, 100, time.UTC)
 	if len(v) != 1 {
 		t.Fatal("expected CalcDateRanges() with a Item count lower than limit to return 1 result")
 	}
 }
 
+func TestCalcDateRangesLocation(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	start := time.Date(2020, 10, 17, 20, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 5)
+
+	v := CalcDateRanges(start, end, OneDay, 2, time.UTC)
+	if !v[0].Start.Equal(time.Date(2020, 10, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected UTC start %v", v[0].Start)
+	}
+	if !v[0].End.Equal(time.Date(2020, 10, 19, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected UTC end %v", v[0].End)
+	}
+
+	// 20:00 UTC is 04:00 on the 18th in UTC+8, whose day starts at 16:00 UTC
+	v = CalcDateRanges(start, end, OneDay, 2, loc)
+	if !v[0].Start.Equal(time.Date(2020, 10, 18, 0, 0, 0, 0, loc)) {
+		t.Errorf("unexpected UTC+8 start %v", v[0].Start)
+	}
+	if !v[0].Start.Equal(time.Date(2020, 10, 17, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected UTC+8 start %v", v[0].Start.UTC())
+	}
+	if !v[0].End.Equal(time.Date(2020, 10, 20, 0, 0, 0, 0, loc)) {
+		t.Errorf("unexpected UTC+8 end %v", v[0].End)
+	}
+	for x := range v {
+		if v[x].Start.In(loc).Hour() != 0 {
+			t.Errorf("expected range %v to start at midnight UTC+8", v[x].Start)
+		}
+	}
+
+	v = CalcDateRanges(start, end, OneDay, 2, nil)
+	if !v[0].Start.Equal(time.Date(2020, 10, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected nil location to align to UTC, received %v", v[0].Start)
+	}
+
+	tn := time.Date(2020, 10, 17, 10, 31, 15, 0, time.UTC)
+	if !TruncateInLocation(tn, FifteenMin, nil).Equal(time.Date(2020, 10, 17, 10, 30, 0, 0, time.UTC)) {
+		t.Error("expected nil location to default to UTC")
+	}
+}
+
+func TestBucketingInLocation(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	// 10:00 and 20:00 UTC share a UTC day but 20:00 UTC is the next day in
+	// UTC+8
+	trades := []order.TradeHistory{
+		{Timestamp: time.Date(2020, 10, 17, 10, 0, 0, 0, time.UTC), Price: 1, Amount: 1},
+		{Timestamp: time.Date(2020, 10, 17, 20, 0, 0, 0, time.UTC), Price: 2, Amount: 1},
+	}
+	utc, err := CreateKline(trades, OneDay, p, asset.Spot, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utc.Candles) != 1 ||
+		!utc.Candles[0].Time.Equal(time.Date(2020, 10, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected UTC candles %+v", utc.Candles)
+	}
+	local, err := CreateKlineInLocation(trades, OneDay, p, asset.Spot, "test", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(local.Candles) != 2 ||
+		!local.Candles[0].Time.Equal(time.Date(2020, 10, 17, 0, 0, 0, 0, loc)) ||
+		!local.Candles[1].Time.Equal(time.Date(2020, 10, 18, 0, 0, 0, 0, loc)) {
+		t.Errorf("unexpected UTC+8 candles %+v", local.Candles)
+	}
+
+	hourly := Item{
+		Interval: OneHour,
+		Candles: []Candle{
+			{Time: time.Date(2020, 10, 17, 10, 0, 0, 0, time.UTC), Open: 1, High: 1, Low: 1, Close: 1, Volume: 1},
+			{Time: time.Date(2020, 10, 17, 20, 0, 0, 0, time.UTC), Open: 2, High: 2, Low: 2, Close: 2, Volume: 1},
+		},
+	}
+	daily, err := hourly.ConvertToNewInterval(OneDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(daily.Candles) != 1 || daily.Candles[0].Volume != 2 {
+		t.Errorf("unexpected UTC daily candles %+v", daily.Candles)
+	}
+	daily, err = hourly.ConvertToNewIntervalInLocation(OneDay, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(daily.Candles) != 2 ||
+		!daily.Candles[1].Time.Equal(time.Date(2020, 10, 18, 0, 0, 0, 0, loc)) ||
+		daily.Candles[1].Open != 2 {
+		t.Errorf("unexpected UTC+8 daily candles %+v", daily.Candles)
+	}
+}
+
+func TestGetResultLimit(t *testing.T) {
+	e := ExchangeCapabilitiesEnabled{
+		ResultLimit: 300,
+		ResultLimits: map[asset.Item]uint32{
+			asset.Futures: 100,
+		},
+	}
+
+	start := time.Unix(1546300800, 0)
+	end := start.Add(200 * time.Minute)
+	total := TotalCandlesPerInterval(start, end, OneMin)
+
+	if e.GetResultLimit(asset.Spot) != 300 {
+		t.Fatalf("expected spot to fall back to default limit, received %v",
+			e.GetResultLimit(asset.Spot))
+	}
+	if total > e.GetResultLimit(asset.Spot) {
+		t.Fatal("expected spot request to be within limits")
+	}
+
+	if e.GetResultLimit(asset.Futures) != 100 {
+		t.Fatalf("expected futures specific limit, received %v",
+			e.GetResultLimit(asset.Futures))
+	}
+	if total <= e.GetResultLimit(asset.Futures) {
+		t.Fatal("expected futures request to exceed limits")
+	}
+}
+
 func TestItem_SortCandlesByTimestamp(t *testing.T) {
 	var tempKline = Item{
 		Exchange: "testExchange",
@@ -445,6 +617,65 @@ func TestItem_SortCandlesByTimestamp(t *testing.T) {
 	}
 }
 
+func TestConvertToNewInterval(t *testing.T) {
+	start := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	oneMin := Item{
+		Exchange: "testExchange",
+		Pair:     currency.NewPair(currency.BTC, currency.USDT),
+		Asset:    asset.Spot,
+		Interval: OneMin,
+	}
+	for x := 0; x < 30; x++ {
+		oneMin.Candles = append(oneMin.Candles, Candle{
+			Time:   start.Add(time.Duration(x) * time.Minute),
+			Open:   float64(x + 1),
+			High:   float64(x + 2),
+			Low:    float64(x),
+			Close:  float64(x + 1),
+			Volume: 1,
+		})
+	}
+
+	_, err := oneMin.ConvertToNewInterval(OneMin)
+	if err == nil {
+		t.Error("expected error when converting to the same interval")
+	}
+
+	_, err = oneMin.ConvertToNewInterval(Interval(90 * time.Second))
+	if err == nil {
+		t.Error("expected error when converting to a non multiple interval")
+	}
+
+	fifteenMin, err := oneMin.ConvertToNewInterval(FifteenMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fifteenMin.Interval != FifteenMin {
+		t.Errorf("expected %v, received %v", FifteenMin, fifteenMin.Interval)
+	}
+	if len(fifteenMin.Candles) != 2 {
+		t.Fatalf("expected 2 candles, received %v", len(fifteenMin.Candles))
+	}
+	first := fifteenMin.Candles[0]
+	if !first.Time.Equal(start) ||
+		first.Open != 1 ||
+		first.High != 16 ||
+		first.Low != 0 ||
+		first.Close != 15 ||
+		first.Volume != 15 {
+		t.Errorf("unexpected aggregated candle %+v", first)
+	}
+	second := fifteenMin.Candles[1]
+	if !second.Time.Equal(start.Add(15*time.Minute)) ||
+		second.Open != 16 ||
+		second.High != 31 ||
+		second.Low != 15 ||
+		second.Close != 30 ||
+		second.Volume != 15 {
+		t.Errorf("unexpected aggregated candle %+v", second)
+	}
+}
+
 func setupTest(t *testing.T) {
 	if verbose {
 		testhelpers.EnableVerboseTestOutput()
@@ -508,6 +739,8 @@ func TestStoreInDatabase(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			ohlcvData.SortCandlesByTimestamp(true)
+			latest := ohlcvData.Candles[0].Time
 			r, err := StoreInDatabase(&ohlcvData)
 			if err != nil {
 				t.Fatal(err)
@@ -516,6 +749,99 @@ func TestStoreInDatabase(t *testing.T) {
 			if r != 365 {
 				t.Fatalf("unexpected number inserted: %v", r)
 			}
+			if !ohlcvData.Candles[len(ohlcvData.Candles)-1].Time.Equal(latest) {
+				t.Error("expected the item's candles to be sorted in place")
+			}
+
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	err := os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		t.Fatalf("Failed to remove temp db file: %v", err)
+	}
+}
+
+func TestStoreInDatabaseBatched(t *testing.T) {
+	setupTest(t)
+
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = seedDB(false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, ohlcvData, err := genOHCLVData()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var progress []uint64
+			r, err := StoreInDatabaseBatched(&ohlcvData, 100, func(stored uint64, total int) {
+				if total != 365 {
+					t.Errorf("expected %v, received %v", 365, total)
+				}
+				progress = append(progress, stored)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r != 365 {
+				t.Fatalf("unexpected number inserted: %v", r)
+			}
+			expected := []uint64{100, 200, 300, 365}
+			if len(progress) != len(expected) {
+				t.Fatalf("expected %v batches, received %v", len(expected), len(progress))
+			}
+			for i := range expected {
+				if progress[i] != expected[i] {
+					t.Errorf("expected %v, received %v", expected[i], progress[i])
+				}
+			}
+
+			// SQLite compares timestamps as strings so widen the range
+			start := time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC)
+			end := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+			ret, err := LoadFromDatabase(testExchanges[0].Name,
+				ohlcvData.Pair, asset.Spot, OneDay, start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ret.Candles) != 365 {
+				t.Errorf("expected %v, received %v", 365, len(ret.Candles))
+			}
 
 			err = testhelpers.CloseDatabase(dbConn)
 			if err != nil {
@@ -588,6 +914,14 @@ func TestLoadFromDatabase(t *testing.T) {
 			if ret.Exchange != testExchanges[0].Name {
 				t.Fatalf("uncorrect data returned: %v", ret.Exchange)
 			}
+			if len(ret.Candles) == 0 {
+				t.Fatal("expected candles to be returned")
+			}
+			if ret.Candles[0].Volume != 1000 || ret.Candles[0].QuoteVolume != 1000000 {
+				t.Errorf("expected base and quote volume to round trip, received %v and %v",
+					ret.Candles[0].Volume,
+					ret.Candles[0].QuoteVolume)
+			}
 
 			err = testhelpers.CloseDatabase(dbConn)
 			if err != nil {
@@ -635,12 +969,13 @@ func genOHCLVData() (out candle.Item, outItem Item, err error) {
 	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
 	for x := 0; x < 365; x++ {
 		out.Candles = append(out.Candles, candle.Candle{
-			Timestamp: start.Add(time.Hour * 24 * time.Duration(x)),
-			Open:      1000,
-			High:      1000,
-			Low:       1000,
-			Close:     1000,
-			Volume:    1000,
+			Timestamp:   start.Add(time.Hour * 24 * time.Duration(x)),
+			Open:        1000,
+			High:        1000,
+			Low:         1000,
+			Close:       1000,
+			Volume:      1000,
+			QuoteVolume: 1000000,
 		})
 	}
 
@@ -651,12 +986,13 @@ func genOHCLVData() (out candle.Item, outItem Item, err error) {
 
 	for x := 0; x < 365; x++ {
 		outItem.Candles = append(outItem.Candles, Candle{
-			Time:   start.Add(time.Hour * 24 * time.Duration(x)),
-			Open:   1000,
-			High:   1000,
-			Low:    1000,
-			Close:  1000,
-			Volume: 1000,
+			Time:        start.Add(time.Hour * 24 * time.Duration(x)),
+			Open:        1000,
+			High:        1000,
+			Low:         1000,
+			Close:       1000,
+			Volume:      1000,
+			QuoteVolume: 1000000,
 		})
 	}
 
@@ -681,3 +1017,505 @@ func TestLoadCSV(t *testing.T) {
 		t.Fatalf("unexpected value received: %v", v[364].Open)
 	}
 }
+
+func TestGetOHLCV(t *testing.T) {
+	t.Parallel()
+	k := Item{
+		Candles: []Candle{
+			{Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10},
+			{Open: 1.5, High: 3, Low: 1, Close: 2.5, Volume: 20},
+		},
+	}
+	o, h, l, c, v := k.GetOHLCV()
+	closes := k.GetClosePrices()
+	volumes := k.GetVolumes()
+	for x := range k.Candles {
+		if o[x] != k.Candles[x].Open ||
+			h[x] != k.Candles[x].High ||
+			l[x] != k.Candles[x].Low ||
+			c[x] != k.Candles[x].Close ||
+			v[x] != k.Candles[x].Volume {
+			t.Errorf("candle %d: unexpected OHLCV %v %v %v %v %v", x, o[x], h[x], l[x], c[x], v[x])
+		}
+		if closes[x] != k.Candles[x].Close {
+			t.Errorf("expected %v, received %v", k.Candles[x].Close, closes[x])
+		}
+		if volumes[x] != k.Candles[x].Volume {
+			t.Errorf("expected %v, received %v", k.Candles[x].Volume, volumes[x])
+		}
+	}
+
+	var empty Item
+	if len(empty.GetClosePrices()) != 0 {
+		t.Error("expected no close prices for an empty item")
+	}
+}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := Item{
+		Candles: []Candle{
+			{Time: start.Add(time.Minute * 2), Close: 3},
+			{Time: start, Close: 1},
+			{Time: start.Add(time.Minute * 3), Close: 4},
+			{Time: start.Add(time.Minute), Close: 2},
+		},
+	}
+	k.Sort()
+	for x := range k.Candles {
+		if !k.Candles[x].Time.Equal(start.Add(time.Minute * time.Duration(x))) {
+			t.Errorf("candle %d: unexpected time %v", x, k.Candles[x].Time)
+		}
+	}
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := Item{
+		Candles: []Candle{
+			{Time: start.Add(time.Minute), Close: 2},
+			{Time: start, Close: 1},
+			{Time: start.Add(time.Minute), Close: 3},
+		},
+	}
+	k.RemoveDuplicates()
+	if len(k.Candles) != 2 {
+		t.Fatalf("expected %v, received %v", 2, len(k.Candles))
+	}
+	if k.Candles[1].Close != 3 {
+		t.Errorf("expected the last duplicate to be kept, received %v", k.Candles[1].Close)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := Item{
+		Candles: []Candle{
+			{Time: start, Close: 1},
+			{Time: start.Add(time.Minute), Close: 2},
+			{Time: start.Add(time.Minute * 2), Close: 3},
+		},
+	}
+	fetched := Item{
+		Candles: []Candle{
+			{Time: start, Close: 1},
+			{Time: start.Add(time.Minute), Close: 2.5},
+			{Time: start.Add(time.Minute * 3), Close: 4},
+		},
+	}
+	diff := stored.Diff(&fetched)
+	if len(diff) != 3 {
+		t.Fatalf("expected %v, received %v", 3, len(diff))
+	}
+	if diff[0].Close != 2.5 {
+		t.Errorf("expected %v, received %v", 2.5, diff[0].Close)
+	}
+	if !diff[1].Time.Equal(start.Add(time.Minute*2)) ||
+		!diff[2].Time.Equal(start.Add(time.Minute*3)) {
+		t.Error("expected missing timestamps to be included in diff")
+	}
+	if d := stored.Diff(&stored); len(d) != 0 {
+		t.Errorf("expected %v, received %v", 0, len(d))
+	}
+	if d := stored.Diff(nil); len(d) != 3 {
+		t.Errorf("expected %v, received %v", 3, len(d))
+	}
+}
+
+func TestPaginateCandles(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute * 25)
+	fetchCandles := func(calls *int) func(s, e time.Time) ([]Candle, error) {
+		return func(s, e time.Time) ([]Candle, error) {
+			*calls++
+			var candles []Candle
+			for ts := s; !ts.After(e); ts = ts.Add(time.Minute) {
+				candles = append(candles, Candle{Time: ts, Close: float64(ts.Minute())})
+			}
+			return candles, nil
+		}
+	}
+
+	e := ExchangeCapabilitiesEnabled{ResultLimit: 10}
+	var calls int
+	candles, err := e.PaginateCandles(asset.Spot, start, end, OneMin, fetchCandles(&calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls < 3 {
+		t.Errorf("expected request to be split into at least %v ranges, received %v", 3, calls)
+	}
+	if len(candles) != 26 {
+		t.Fatalf("expected %v, received %v", 26, len(candles))
+	}
+	for x := range candles {
+		if !candles[x].Time.Equal(start.Add(time.Minute * time.Duration(x))) {
+			t.Fatalf("candle %d: unexpected time %v", x, candles[x].Time)
+		}
+	}
+
+	calls = 0
+	e = ExchangeCapabilitiesEnabled{}
+	_, err = e.PaginateCandles(asset.Spot, start, end, OneMin, fetchCandles(&calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("expected %v, received %v", 1, calls)
+	}
+
+	errTest := errors.New("test error")
+	e = ExchangeCapabilitiesEnabled{ResultLimit: 10}
+	_, err = e.PaginateCandles(asset.Spot, start, end, OneMin, func(time.Time, time.Time) ([]Candle, error) {
+		return nil, errTest
+	})
+	if !errors.Is(err, errTest) {
+		t.Errorf("expected %v, received %v", errTest, err)
+	}
+}
+
+func TestNewItem(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	_, err := NewItem("", p, asset.Spot, OneMin)
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Errorf("expected %v, received %v", errExchangeNameUnset, err)
+	}
+	_, err = NewItem("Binance", currency.Pair{}, asset.Spot, OneMin)
+	if !errors.Is(err, errPairUnset) {
+		t.Errorf("expected %v, received %v", errPairUnset, err)
+	}
+	_, err = NewItem("Binance", p, "lol", OneMin)
+	if !errors.Is(err, errAssetInvalid) {
+		t.Errorf("expected %v, received %v", errAssetInvalid, err)
+	}
+	_, err = NewItem("Binance", p, asset.Spot, 0)
+	if !errors.Is(err, errIntervalUnset) {
+		t.Errorf("expected %v, received %v", errIntervalUnset, err)
+	}
+	k, err := NewItem("Binance", p, asset.Spot, OneMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Exchange != "Binance" || !k.Pair.Equal(p) || k.Asset != asset.Spot ||
+		k.Interval != OneMin {
+		t.Errorf("unexpected item %+v", k)
+	}
+}
+
+func TestAddCandles(t *testing.T) {
+	t.Parallel()
+	tn := time.Date(2020, 10, 17, 10, 0, 0, 0, time.UTC)
+	var k Item
+	k.AddCandle(Candle{Time: tn.Add(2 * time.Minute), Close: 3})
+	k.AddCandle(Candle{Time: tn, Close: 1})
+	k.AddCandle(Candle{Time: tn.Add(time.Minute), Close: 2})
+	k.AddCandle(Candle{Time: tn.Add(time.Minute), Close: 4})
+	k.AddCandles([]Candle{
+		{Time: tn.Add(4 * time.Minute), Close: 6},
+		{Time: tn.Add(3 * time.Minute), Close: 5},
+	})
+	expected := []float64{1, 2, 4, 3, 5, 6}
+	if len(k.Candles) != len(expected) {
+		t.Fatalf("expected %v, received %v", len(expected), len(k.Candles))
+	}
+	for x := range expected {
+		if k.Candles[x].Close != expected[x] {
+			t.Errorf("expected %v, received %v at %d", expected[x], k.Candles[x].Close, x)
+		}
+	}
+}
+
+func TestValidateInterval(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := Item{
+		Exchange: "test",
+		Pair:     currency.NewPair(currency.BTC, currency.USDT),
+		Asset:    asset.Spot,
+		Interval: OneMin,
+	}
+	for x := 0; x < 5; x++ {
+		k.Candles = append(k.Candles, Candle{Time: start.Add(time.Duration(x) * FiveMin.Duration())})
+	}
+
+	err := k.ValidateInterval()
+	if !errors.Is(err, errIntervalMismatch) {
+		t.Errorf("expected %v, received %v", errIntervalMismatch, err)
+	}
+	_, err = StoreInDatabaseVerified(&k)
+	if !errors.Is(err, errIntervalMismatch) {
+		t.Errorf("expected %v, received %v", errIntervalMismatch, err)
+	}
+
+	k.Interval = FiveMin
+	err = k.ValidateInterval()
+	if err != nil {
+		t.Errorf("expected %v, received %v", nil, err)
+	}
+
+	// whole interval gaps are permitted
+	k.Candles = append(k.Candles, Candle{Time: start.Add(FiveMin.Duration() * 10)})
+	err = k.ValidateInterval()
+	if err != nil {
+		t.Errorf("expected %v, received %v", nil, err)
+	}
+
+	k.Candles = append(k.Candles, Candle{Time: start.Add(FiveMin.Duration()*11 + time.Minute)})
+	err = k.ValidateInterval()
+	if !errors.Is(err, errIntervalMismatch) {
+		t.Errorf("expected %v, received %v", errIntervalMismatch, err)
+	}
+}
+
+func TestIntervalArithmetic(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		interval, other Interval
+		ratio           int
+		multiple        bool
+	}{
+		{FiveMin, OneMin, 5, true},
+		{OneMin, FiveMin, 0, false},
+		{FiveMin, Interval(time.Minute * 2), 2, false},
+		{OneHour, OneHour, 1, true},
+		{OneDay, OneHour, 24, true},
+		{OneMin, 0, 0, false},
+		{0, OneMin, 0, false},
+	} {
+		ratio, exact := tc.interval.Ratio(tc.other)
+		if ratio != tc.ratio || exact != tc.multiple {
+			t.Errorf("%s ratio %s: expected %v %v, received %v %v",
+				tc.interval, tc.other, tc.ratio, tc.multiple, ratio, exact)
+		}
+		if multiple := tc.interval.IsMultipleOf(tc.other); multiple != tc.multiple {
+			t.Errorf("%s multiple of %s: expected %v, received %v",
+				tc.interval, tc.other, tc.multiple, multiple)
+		}
+	}
+}
+
+func TestLatestClosedCandle(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2020, 10, 1, 12, 30, 0, 0, time.UTC)
+	current := now.Truncate(time.Hour)
+	_, err := LatestClosedCandle(nil, OneHour, now)
+	if !errors.Is(err, ErrNoClosedCandle) {
+		t.Errorf("expected %v, received %v", ErrNoClosedCandle, err)
+	}
+
+	candles := []Candle{
+		{Time: current.Add(-time.Hour), Close: 2},
+		{Time: current, Close: 3},
+		{Time: current.Add(-time.Hour * 2), Close: 1},
+	}
+	c, err := LatestClosedCandle(candles, OneHour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Time.Equal(current.Add(-time.Hour)) || c.Close != 2 {
+		t.Errorf("expected candle at %v, received %v", current.Add(-time.Hour), c.Time)
+	}
+
+	_, err = LatestClosedCandle(candles[1:2], OneHour, now)
+	if !errors.Is(err, ErrNoClosedCandle) {
+		t.Errorf("expected %v, received %v", ErrNoClosedCandle, err)
+	}
+}
+
+func TestDatabaseCandlePrecision(t *testing.T) {
+	c := Candle{
+		Time:        time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+		Open:        10.123456789,
+		High:        11.987654321,
+		Low:         9.000000001,
+		Close:       10.555555555,
+		Volume:      1.23456789,
+		QuoteVolume: 12.3456789,
+	}
+
+	out := databaseCandle(&c, int(atomic.LoadInt32(&storePrecision)))
+	if out.Open != c.Open || out.Volume != c.Volume {
+		t.Errorf("expected unrounded values by default, received %+v", out)
+	}
+
+	SetStorePrecision(4)
+	defer SetStorePrecision(-1)
+	out = databaseCandle(&c, int(atomic.LoadInt32(&storePrecision)))
+	if !out.Timestamp.Equal(c.Time) {
+		t.Errorf("expected %v, received %v", c.Time, out.Timestamp)
+	}
+	for _, v := range []struct {
+		received, expected float64
+	}{
+		{out.Open, 10.1235},
+		{out.High, 11.9877},
+		{out.Low, 9},
+		{out.Close, 10.5556},
+		{out.Volume, 1.2346},
+		{out.QuoteVolume, 12.3457},
+	} {
+		if v.received != v.expected {
+			t.Errorf("expected %v, received %v", v.expected, v.received)
+		}
+	}
+
+	SetStorePrecision(-5)
+	if p := atomic.LoadInt32(&storePrecision); p != -1 {
+		t.Errorf("expected %v, received %v", -1, p)
+	}
+}
+
+func TestToCSV(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	item := Item{
+		Candles: []Candle{
+			{Time: start, Open: 1, High: 2.5, Low: 0.5, Close: 2, Volume: 10},
+			{Time: start.Add(time.Hour), Open: 2, High: 3, Low: 1.5, Close: 2.5, Volume: 12},
+		},
+	}
+	var b strings.Builder
+	err := item.ToCSV(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(rows) != len(item.Candles)+1 {
+		t.Fatalf("expected %v, received %v", len(item.Candles)+1, len(rows))
+	}
+	if rows[0] != "time,open,high,low,close,volume" {
+		t.Errorf("expected %v, received %v", "time,open,high,low,close,volume", rows[0])
+	}
+	if rows[1] != "2020-01-01T00:00:00Z,1,2.5,0.5,2,10" {
+		t.Errorf("expected %v, received %v", "2020-01-01T00:00:00Z,1,2.5,0.5,2,10", rows[1])
+	}
+
+	item.Candles[1].QuoteVolume = 30
+	b.Reset()
+	err = item.ToCSV(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows = strings.Split(strings.TrimSpace(b.String()), "\n")
+	if rows[0] != "time,open,high,low,close,volume,quote_volume" {
+		t.Errorf("expected %v, received %v", "time,open,high,low,close,volume,quote_volume", rows[0])
+	}
+	if rows[2] != "2020-01-01T01:00:00Z,2,3,1.5,2.5,12,30" {
+		t.Errorf("expected %v, received %v", "2020-01-01T01:00:00Z,2,3,1.5,2.5,12,30", rows[2])
+	}
+}
+
+func TestLoadCandlesFromCSV(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	data := "time,open,high,low,close,volume,quote_volume\n" +
+		"2020-01-01T00:00:00Z,1,2.5,0.5,2,10,20\n" +
+		"2020-01-01T01:00:00Z,2,3,1.5,2.5,12,30\n"
+	item, err := LoadCandlesFromCSV(strings.NewReader(data), "test", p, asset.Spot, OneHour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Exchange != "test" || !item.Pair.Equal(p) || item.Asset != asset.Spot || item.Interval != OneHour {
+		t.Errorf("unexpected item details %+v", item)
+	}
+	expected := []Candle{
+		{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Open: 1, High: 2.5, Low: 0.5, Close: 2, Volume: 10, QuoteVolume: 20},
+		{Time: time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC), Open: 2, High: 3, Low: 1.5, Close: 2.5, Volume: 12, QuoteVolume: 30},
+	}
+	if len(item.Candles) != len(expected) {
+		t.Fatalf("expected %v, received %v", len(expected), len(item.Candles))
+	}
+	for x := range expected {
+		if !candlesEqual(item.Candles[x], expected[x]) {
+			t.Errorf("expected %+v, received %+v", expected[x], item.Candles[x])
+		}
+	}
+
+	var b strings.Builder
+	err = item.ToCSV(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != data {
+		t.Errorf("expected round trip %q, received %q", data, b.String())
+	}
+
+	_, err = LoadCandlesFromCSV(strings.NewReader("2020-01-01T01:00:00Z,1,1,1,1,1\n2020-01-01T00:00:00Z,1,1,1,1,1\n"), "test", p, asset.Spot, OneHour)
+	if !errors.Is(err, errCSVTimeOrder) {
+		t.Errorf("expected %v, received %v", errCSVTimeOrder, err)
+	}
+	_, err = LoadCandlesFromCSV(strings.NewReader("2020-01-01T00:00:00Z,1,1,1\n"), "test", p, asset.Spot, OneHour)
+	if !errors.Is(err, errCSVColumnCount) {
+		t.Errorf("expected %v, received %v", errCSVColumnCount, err)
+	}
+	_, err = LoadCandlesFromCSV(strings.NewReader("2020-01-01T00:00:00Z,1,x,1,1,1\n"), "test", p, asset.Spot, OneHour)
+	if !errors.Is(err, convert.ErrInvalidNumericField) {
+		t.Errorf("expected %v, received %v", convert.ErrInvalidNumericField, err)
+	}
+}
+
+func TestTypedValidationErrors(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	item := Item{
+		Exchange: "test",
+		Pair:     currency.NewPair(currency.BTC, currency.USDT),
+		Asset:    asset.Spot,
+		Interval: OneHour,
+	}
+
+	var noCandles *ErrNoCandles
+	err := item.Validate()
+	if !errors.As(err, &noCandles) {
+		t.Errorf("expected %T, received %v", noCandles, err)
+	}
+	_, err = item.ConvertToNewInterval(FourHour)
+	if !errors.As(err, &noCandles) {
+		t.Errorf("expected %T, received %v", noCandles, err)
+	}
+
+	item.Candles = []Candle{
+		{Time: start.Add(time.Hour)},
+		{Time: start},
+	}
+	var unsorted *ErrUnsortedCandles
+	err = item.Validate()
+	if !errors.As(err, &unsorted) {
+		t.Fatalf("expected %T, received %v", unsorted, err)
+	}
+	if unsorted.Index != 1 || !unsorted.Current.Equal(start) {
+		t.Errorf("unexpected unsorted candle details %+v", unsorted)
+	}
+
+	item.Sort()
+	if err = item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	item.AddCandle(Candle{Time: start.Add(time.Hour * 2).Add(time.Minute)})
+	var uneven *ErrUnevenIntervals
+	err = item.Validate()
+	if !errors.As(err, &uneven) {
+		t.Fatalf("expected %T, received %v", uneven, err)
+	}
+	if uneven.Interval != OneHour || !uneven.Previous.Equal(start.Add(time.Hour)) {
+		t.Errorf("unexpected uneven interval details %+v", uneven)
+	}
+	if !errors.Is(err, errIntervalMismatch) {
+		t.Errorf("expected %v, received %v", errIntervalMismatch, err)
+	}
+
+	var klineErr *ErrorKline
+	_, err = item.ConvertToNewInterval(Interval(90 * time.Minute))
+	if !errors.As(err, &klineErr) {
+		t.Fatalf("expected %T, received %v", klineErr, err)
+	}
+	if klineErr.Interval != Interval(90*time.Minute) || !klineErr.Pair.Equal(item.Pair) {
+		t.Errorf("unexpected kline error details %+v", klineErr)
+	}
+}
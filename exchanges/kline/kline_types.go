@@ -1,6 +1,8 @@
 package kline
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -10,6 +12,7 @@ import (
 // Consts here define basic time intervals
 const (
 	FifteenSecond = Interval(15 * time.Second)
+	ThirtySecond  = 2 * FifteenSecond
 	OneMin        = Interval(time.Minute)
 	ThreeMin      = 3 * OneMin
 	FiveMin       = 5 * OneMin
@@ -33,8 +36,34 @@ const (
 )
 
 const (
+	// DefaultStoreBatchSize is the number of candles written to the database
+	// per transaction when storing an item
+	DefaultStoreBatchSize = 1000
+
 	// ErrRequestExceedsExchangeLimits locale for exceeding rate limits message
 	ErrRequestExceedsExchangeLimits = "requested data would exceed exchange limits please lower range or use GetHistoricCandlesEx"
+
+	// LatestCandleLookback is the number of intervals requested when fetching
+	// the latest closed candle, allowing for the in progress candle
+	LatestCandleLookback = 3
+)
+
+// storePrecision is the number of decimals candle values are rounded to
+// before being stored, a negative value stores them unrounded
+var storePrecision int32 = -1
+
+var (
+	// ErrNoClosedCandle is returned when no candle has closed within the
+	// requested candles
+	ErrNoClosedCandle = errors.New("no closed candle available")
+
+	errExchangeNameUnset = errors.New("exchange name unset")
+	errPairUnset         = errors.New("currency pair unset")
+	errAssetInvalid      = errors.New("asset type invalid")
+	errIntervalUnset     = errors.New("interval unset")
+	errIntervalMismatch  = errors.New("candle spacing does not match interval")
+	errCSVColumnCount    = errors.New("unexpected CSV column count")
+	errCSVTimeOrder      = errors.New("CSV candle timestamps are not ascending")
 )
 
 // Item holds all the relevant information for internal kline elements
@@ -54,6 +83,12 @@ type Candle struct {
 	Low    float64
 	Close  float64
 	Volume float64
+	// QuoteVolume is the volume in the quote currency, set when provided by
+	// the exchange or summed from trade price by amount in CreateKline
+	QuoteVolume float64
+	// TakerBuyVolume is the volume bought by takers, this is only set when
+	// provided by the exchange and is not persisted to the candle store
+	TakerBuyVolume float64
 }
 
 // ExchangeCapabilitiesSupported all kline related exchange supported options
@@ -66,6 +101,8 @@ type ExchangeCapabilitiesSupported struct {
 type ExchangeCapabilitiesEnabled struct {
 	Intervals   map[string]bool `json:"intervals,omitempty"`
 	ResultLimit uint32
+	// ResultLimits overrides ResultLimit for specific asset types
+	ResultLimits map[asset.Item]uint32 `json:"resultLimits,omitempty"`
 }
 
 // Interval type for kline Interval usage
@@ -89,6 +126,57 @@ func (k *ErrorKline) Unwrap() error {
 	return k.Err
 }
 
+// ErrNoCandles is returned when an item holds no candles to operate on
+type ErrNoCandles struct {
+	Exchange string
+	Pair     currency.Pair
+	Asset    asset.Item
+}
+
+// Error returns the item details lacking candles
+func (e *ErrNoCandles) Error() string {
+	return fmt.Sprintf("%s %s %s has no candles", e.Exchange, e.Pair, e.Asset)
+}
+
+// ErrUnsortedCandles is returned when a candle is earlier than the candle
+// before it
+type ErrUnsortedCandles struct {
+	Index    int
+	Previous time.Time
+	Current  time.Time
+}
+
+// Error returns the position of the out of order candle
+func (e *ErrUnsortedCandles) Error() string {
+	return fmt.Sprintf("candle %d at %v is before the previous candle at %v",
+		e.Index,
+		e.Current,
+		e.Previous)
+}
+
+// ErrUnevenIntervals is returned when the spacing between two candles does not
+// match the item's interval
+type ErrUnevenIntervals struct {
+	Interval Interval
+	Previous time.Time
+	Current  time.Time
+}
+
+// Error returns the mismatching candles and their spacing
+func (e *ErrUnevenIntervals) Error() string {
+	return fmt.Sprintf("%s %s: candles at %v and %v are %v apart",
+		errIntervalMismatch,
+		e.Interval.Short(),
+		e.Previous,
+		e.Current,
+		e.Current.Sub(e.Previous))
+}
+
+// Unwrap returns the underlying interval mismatch error
+func (e *ErrUnevenIntervals) Unwrap() error {
+	return errIntervalMismatch
+}
+
 // DateRange holds a start and end date for kline usage
 type DateRange struct {
 	Start time.Time
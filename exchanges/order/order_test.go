@@ -2,6 +2,7 @@ package order
 
 import (
 	"errors"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -949,3 +950,44 @@ func TestClassificationError_Error(t *testing.T) {
 		t.Fatal("unexpected output")
 	}
 }
+
+func TestContractConversion(t *testing.T) {
+	t.Parallel()
+	const contractSize = 0.01
+	contracts, err := ContractsFromAmount(1.5, contractSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contracts != 150 {
+		t.Errorf("expected %v, received %v", 150, contracts)
+	}
+	amount, err := AmountFromContracts(contracts, contractSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(amount-1.5) > 1e-12 {
+		t.Errorf("expected %v, received %v", 1.5, amount)
+	}
+
+	// 0.07 / 0.01 is not exact in floating point
+	contracts, err = ContractsFromAmount(0.07, contractSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contracts != 7 {
+		t.Errorf("expected %v, received %v", 7, contracts)
+	}
+
+	_, err = ContractsFromAmount(0.015, 0.01)
+	if !errors.Is(err, ErrFractionalContracts) {
+		t.Errorf("expected %v, received %v", ErrFractionalContracts, err)
+	}
+	_, err = ContractsFromAmount(1, 0)
+	if !errors.Is(err, ErrContractSizeInvalid) {
+		t.Errorf("expected %v, received %v", ErrContractSizeInvalid, err)
+	}
+	_, err = AmountFromContracts(1, -1)
+	if !errors.Is(err, ErrContractSizeInvalid) {
+		t.Errorf("expected %v, received %v", ErrContractSizeInvalid, err)
+	}
+}
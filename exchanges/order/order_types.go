@@ -16,6 +16,8 @@ var (
 	ErrTypeIsInvalid              = errors.New("order type is invalid")
 	ErrAmountIsInvalid            = errors.New("order amount is invalid")
 	ErrPriceMustBeSetIfLimitOrder = errors.New("order price must be set if limit order type is desired")
+	ErrContractSizeInvalid        = errors.New("contract size must be greater than zero")
+	ErrFractionalContracts        = errors.New("amount is not a whole number of contracts")
 )
 
 // Submit contains all properties of an order that may be required
@@ -27,6 +29,7 @@ type Submit struct {
 	HiddenOrder       bool
 	FillOrKill        bool
 	PostOnly          bool
+	ReduceOnly        bool
 	Leverage          string
 	Price             float64
 	Amount            float64
@@ -58,7 +61,10 @@ type Submit struct {
 type SubmitResponse struct {
 	IsOrderPlaced bool
 	FullyMatched  bool
+	FilledAmount  float64
 	OrderID       string
+	Status        Status
+	RejectReason  string
 }
 
 // Modify contains all properties of an order
@@ -3,6 +3,7 @@ package order
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -42,6 +43,38 @@ func (s *Submit) Validate() error {
 	return nil
 }
 
+// contractTolerance absorbs floating point error when checking an amount is a
+// whole number of contracts
+const contractTolerance = 1e-9
+
+// ContractsFromAmount converts an amount in base currency units into the
+// number of contracts of contractSize it represents. Amounts which are not a
+// whole number of contracts are rejected rather than rounded so an order is
+// never resized
+func ContractsFromAmount(amount, contractSize float64) (float64, error) {
+	if contractSize <= 0 {
+		return 0, ErrContractSizeInvalid
+	}
+	contracts := amount / contractSize
+	whole := math.Round(contracts)
+	if math.Abs(contracts-whole) > contractTolerance*math.Max(1, math.Abs(contracts)) {
+		return 0, fmt.Errorf("%w: %v at contract size %v",
+			ErrFractionalContracts,
+			amount,
+			contractSize)
+	}
+	return whole, nil
+}
+
+// AmountFromContracts converts a number of contracts of contractSize into an
+// amount in base currency units
+func AmountFromContracts(contracts, contractSize float64) (float64, error) {
+	if contractSize <= 0 {
+		return 0, ErrContractSizeInvalid
+	}
+	return contracts * contractSize, nil
+}
+
 // UpdateOrderFromDetail Will update an order detail (used in order management)
 // by comparing passed in and existing values
 func (d *Detail) UpdateOrderFromDetail(m *Detail) {
@@ -20,3 +20,24 @@ func LinearBackoff(base, max time.Duration) Backoff {
 		return d
 	}
 }
+
+// ExponentialBackoff applies a backoff doubling from a base amount with each
+// retry capped at a maximum duration.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(n int) time.Duration {
+		if n < 1 {
+			n = 1
+		}
+		d := base
+		for i := 1; i < n; i++ {
+			d *= 2
+			if d >= max {
+				return max
+			}
+		}
+		if d > max {
+			return max
+		}
+		return d
+	}
+}
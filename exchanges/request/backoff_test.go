@@ -77,3 +77,20 @@ func TestLinearBackoff(t *testing.T) {
 		})
 	}
 }
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+	b := request.ExponentialBackoff(100*time.Millisecond, time.Second)
+	for n, exp := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		64: time.Second,
+	} {
+		if got := b(n); got != exp {
+			t.Errorf("incorrect backoff duration for attempt %d\nexp: %s\ngot: %s", n, exp, got)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package request
 
 import (
 	"errors"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -15,10 +16,15 @@ const (
 	UnAuth
 )
 
+var (
+	errLimiterNotSet            = errors.New("rate limiter not set")
+	errLimiterStatusUnsupported = errors.New("rate limiter does not support status reporting")
+)
+
 // BasicLimit denotes basic rate limit that implements the Limiter interface
 // does not need to set endpoint functionality.
 type BasicLimit struct {
-	r *rate.Limiter
+	r *TrackedLimiter
 }
 
 // Limit executes a single rate limit set by NewRateLimit
@@ -38,6 +44,81 @@ type Limiter interface {
 	Limit(EndpointLimit) error
 }
 
+// StatusReporter is implemented by limiters which can report the state of
+// their rate limit buckets keyed by bucket name
+type StatusReporter interface {
+	Status() map[string]LimitStatus
+}
+
+// LimitStatus defines the state of a single rate limit bucket
+type LimitStatus struct {
+	// Remaining is the number of tokens available now, this is negative when
+	// requests are queued waiting on the bucket
+	Remaining float64
+	Burst     int
+	// RefillIn is the time until the next token becomes available
+	RefillIn time.Duration
+}
+
+// TrackedLimiter wraps a rate.Limiter and mirrors its token bucket so the
+// bucket state can be reported without reserving tokens from the live
+// limiter, which would delay requests waiting on it
+type TrackedLimiter struct {
+	*rate.Limiter
+	m      sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTrackedRateLimit returns a rate limiter which can report its state, see
+// NewRateLimit
+func NewTrackedRateLimit(interval time.Duration, actions int) *TrackedLimiter {
+	l := NewRateLimit(interval, actions)
+	return &TrackedLimiter{Limiter: l, tokens: float64(l.Burst())}
+}
+
+// Reserve reserves a single token from the limiter and records it against
+// the mirrored bucket
+func (t *TrackedLimiter) Reserve() *rate.Reservation {
+	t.m.Lock()
+	defer t.m.Unlock()
+	now := time.Now()
+	t.tokens = t.tokensAt(now) - 1
+	t.last = now
+	return t.Limiter.ReserveN(now, 1)
+}
+
+// Status returns the current state of the mirrored bucket
+func (t *TrackedLimiter) Status() LimitStatus {
+	t.m.Lock()
+	defer t.m.Unlock()
+	s := LimitStatus{
+		Remaining: t.tokensAt(time.Now()),
+		Burst:     t.Burst(),
+	}
+	if s.Remaining < 1 && t.Limit() != rate.Inf {
+		s.RefillIn = time.Duration((1 - s.Remaining) / float64(t.Limit()) * float64(time.Second))
+	}
+	return s
+}
+
+// tokensAt returns the mirrored token count refilled up to now, the same way
+// rate.Limiter advances its own bucket
+func (t *TrackedLimiter) tokensAt(now time.Time) float64 {
+	burst := float64(t.Burst())
+	if t.Limit() == rate.Inf {
+		return burst
+	}
+	if t.last.IsZero() || now.Before(t.last) {
+		return t.tokens
+	}
+	tokens := t.tokens + now.Sub(t.last).Seconds()*float64(t.Limit())
+	if tokens > burst {
+		return burst
+	}
+	return tokens
+}
+
 // NewRateLimit creates a new RateLimit based of time interval and how many
 // actions allowed and breaks it down to an actions-per-second basis -- Burst
 // rate is kept as one as this is not supported for out-bound requests.
@@ -52,10 +133,15 @@ func NewRateLimit(interval time.Duration, actions int) *rate.Limiter {
 	return rate.NewLimiter(rate.Limit(rps), 1)
 }
 
+// Status returns the state of the basic rate limit bucket
+func (b *BasicLimit) Status() map[string]LimitStatus {
+	return map[string]LimitStatus{"basic": b.r.Status()}
+}
+
 // NewBasicRateLimit returns an object that implements the limiter interface
 // for basic rate limit
 func NewBasicRateLimit(interval time.Duration, actions int) Limiter {
-	return &BasicLimit{NewRateLimit(interval, actions)}
+	return &BasicLimit{NewTrackedRateLimit(interval, actions)}
 }
 
 // InitiateRateLimit sleeps for designated end point rate limits
@@ -71,6 +157,19 @@ func (r *Requester) InitiateRateLimit(e EndpointLimit) error {
 	return nil
 }
 
+// GetRateLimitStatus returns the state of each rate limit bucket so callers
+// can back off before requests are delayed
+func (r *Requester) GetRateLimitStatus() (map[string]LimitStatus, error) {
+	if r.limiter == nil {
+		return nil, errLimiterNotSet
+	}
+	reporter, ok := r.limiter.(StatusReporter)
+	if !ok {
+		return nil, errLimiterStatusUnsupported
+	}
+	return reporter.Status(), nil
+}
+
 // DisableRateLimiter disables the rate limiting system for the exchange
 func (r *Requester) DisableRateLimiter() error {
 	if !atomic.CompareAndSwapInt32(&r.disableRateLimiter, 0, 1) {
@@ -1,5 +1,7 @@
 package request
 
+import "time"
+
 // WithBackoff configures the backoff strategy for a Requester.
 func WithBackoff(b Backoff) RequesterOption {
 	return func(r *Requester) {
@@ -20,3 +22,11 @@ func WithRetryPolicy(p RetryPolicy) RequesterOption {
 		r.retryPolicy = p
 	}
 }
+
+// WithSlowRequestThreshold configures the response time above which a
+// Requester logs a request as slow.
+func WithSlowRequestThreshold(d time.Duration) RequesterOption {
+	return func(r *Requester) {
+		r.slowThreshold = d
+	}
+}
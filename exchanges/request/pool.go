@@ -0,0 +1,62 @@
+package request
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var errInvalidWorkerCount = errors.New("worker count must be greater than zero")
+
+// maxWorkers bounds the number of fan out workers by the request job slots
+// still available so workers never trip the requester job limit
+func (r *Requester) maxWorkers(workers int) int {
+	available := int(MaxRequestJobs - atomic.LoadInt32(&r.jobs))
+	if available < 1 {
+		available = 1
+	}
+	if workers > available {
+		return available
+	}
+	return workers
+}
+
+// FanOut calls fn for each job index in [0, jobs) using at most workers
+// goroutines. Each call is expected to send its requests through the exchange
+// requester so the rate limiter is respected. No further jobs are dispatched
+// after the first error, which is returned once all running jobs complete
+func (r *Requester) FanOut(workers, jobs int, fn func(job int) error) error {
+	if workers <= 0 {
+		return errInvalidWorkerCount
+	}
+	workers = r.maxWorkers(workers)
+	if workers > jobs {
+		workers = jobs
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		failed   int32
+	)
+	queue := make(chan int)
+	wg.Add(workers)
+	for x := 0; x < workers; x++ {
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				if err := fn(job); err != nil {
+					errOnce.Do(func() { firstErr = err })
+					atomic.StoreInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+	for x := 0; x < jobs && atomic.LoadInt32(&failed) == 0; x++ {
+		queue <- x
+	}
+	close(queue)
+	wg.Wait()
+	return firstErr
+}
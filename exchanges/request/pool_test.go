@@ -0,0 +1,53 @@
+package request
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFanOut(t *testing.T) {
+	t.Parallel()
+	r := New("test", nil)
+	err := r.FanOut(0, 10, func(int) error { return nil })
+	if !errors.Is(err, errInvalidWorkerCount) {
+		t.Errorf("expected %v, received %v", errInvalidWorkerCount, err)
+	}
+
+	const bound = 3
+	var running, peak, completed int32
+	err = r.FanOut(bound, 20, func(int) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if current <= p || atomic.CompareAndSwapInt32(&peak, p, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 5)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&completed, 1)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peak > bound {
+		t.Errorf("expected concurrency to be bounded by %v, received %v", bound, peak)
+	}
+	if completed != 20 {
+		t.Errorf("expected %v, received %v", 20, completed)
+	}
+
+	errTest := errors.New("test error")
+	err = r.FanOut(bound, 100, func(job int) error {
+		if job == 0 {
+			return errTest
+		}
+		return nil
+	})
+	if !errors.Is(err, errTest) {
+		t.Errorf("expected %v, received %v", errTest, err)
+	}
+}
@@ -144,7 +144,11 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 			return err
 		}
 
+		start := time.Now()
 		resp, err := r.HTTPClient.Do(req)
+		if elapsed := time.Since(start); r.slowThreshold > 0 && elapsed > r.slowThreshold {
+			logSlow(r.Name, req, elapsed, r.slowThreshold)
+		}
 		if retry, checkErr := r.retryPolicy(resp, err); checkErr != nil {
 			return checkErr
 		} else if retry {
@@ -174,7 +178,7 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 				delay = after
 			}
 
-			if d, ok := req.Context().Deadline(); ok && d.After(time.Now().Add(delay)) {
+			if d, ok := req.Context().Deadline(); ok && !d.After(time.Now().Add(delay)) {
 				if err != nil {
 					return fmt.Errorf("request.go error - deadline would be exceeded by retry, err: %v", err)
 				}
@@ -249,6 +253,25 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 	}
 }
 
+// SetSlowRequestThreshold sets the response time above which requests are
+// logged as slow, zero disables slow request logging
+func (r *Requester) SetSlowRequestThreshold(d time.Duration) {
+	r.slowThreshold = d
+}
+
+// logSlowRequest warns of a request which took longer than the threshold to
+// respond. The query string is omitted as it may carry signatures
+func logSlowRequest(name string, req *http.Request, elapsed, threshold time.Duration) {
+	log.Warnf(log.RequestSys,
+		"%s %s request to %s%s took %s, exceeding slow request threshold of %s",
+		name,
+		req.Method,
+		req.URL.Host,
+		req.URL.Path,
+		elapsed,
+		threshold)
+}
+
 // GetNonce returns a nonce for requests. This locks and enforces concurrent
 // nonce FIFO on the buffered job channel
 func (r *Requester) GetNonce(isNano bool) nonce.Value {
@@ -584,3 +584,126 @@ func TestEnableDisableRateLimit(t *testing.T) {
 		// Correct test
 	}
 }
+
+func TestGetRateLimitStatus(t *testing.T) {
+	t.Parallel()
+	_, err := New("test", new(http.Client)).GetRateLimitStatus()
+	if !errors.Is(err, errLimiterNotSet) {
+		t.Errorf("expected %v, received %v", errLimiterNotSet, err)
+	}
+
+	r := New("test",
+		new(http.Client),
+		WithLimiter(NewBasicRateLimit(time.Minute, 1)))
+	status, err := r.GetRateLimitStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := status["basic"]
+	if before.Remaining != 1 || before.RefillIn != 0 {
+		t.Errorf("unexpected initial limit status %+v", before)
+	}
+
+	var resp interface{}
+	err = r.SendPayload(context.Background(), &Item{
+		Method:   http.MethodGet,
+		Path:     testURL,
+		Result:   &resp,
+		Endpoint: Auth,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = r.GetRateLimitStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := status["basic"]
+	if after.Remaining >= before.Remaining {
+		t.Errorf("expected remaining tokens to decrease from %v, received %v",
+			before.Remaining,
+			after.Remaining)
+	}
+	if after.RefillIn <= 0 {
+		t.Error("expected a refill delay once the bucket is empty")
+	}
+
+	status, err = r.GetRateLimitStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status["basic"].Remaining > after.Remaining+0.01 {
+		t.Error("expected status reporting to not consume or return tokens")
+	}
+}
+
+func TestTrackedLimiterStatus(t *testing.T) {
+	t.Parallel()
+	l := NewTrackedRateLimit(time.Minute, 1)
+	for i := 0; i < 5; i++ {
+		if s := l.Status(); s.Remaining != 1 || s.RefillIn != 0 {
+			t.Fatalf("unexpected limit status %+v", s)
+		}
+	}
+	// Reporting status must leave the live limiter untouched
+	if d := l.Reserve().Delay(); d != 0 {
+		t.Errorf("expected no delay after status checks, received %v", d)
+	}
+	s := l.Status()
+	if s.Remaining >= 1 || s.RefillIn <= 0 || s.RefillIn > time.Minute {
+		t.Errorf("unexpected limit status after reservation %+v", s)
+	}
+
+	inf := NewTrackedRateLimit(0, 0)
+	inf.Reserve()
+	if s := inf.Status(); s.Remaining != 1 || s.RefillIn != 0 {
+		t.Errorf("unexpected unrestricted limit status %+v", s)
+	}
+}
+
+func TestSlowRequestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/slow" {
+			time.Sleep(time.Millisecond * 50)
+		}
+		io.WriteString(w, `{"response":true}`)
+	}))
+	defer server.Close()
+
+	var logged []string
+	logSlow = func(_ string, req *http.Request, elapsed, threshold time.Duration) {
+		if elapsed <= threshold {
+			t.Errorf("elapsed %v should exceed threshold %v", elapsed, threshold)
+		}
+		logged = append(logged, req.URL.Path)
+	}
+	defer func() { logSlow = logSlowRequest }()
+
+	r := New("test", new(http.Client), WithSlowRequestThreshold(time.Millisecond*20))
+	for _, path := range []string{"/fast", "/slow"} {
+		err := r.SendPayload(context.Background(), &Item{
+			Method: http.MethodGet,
+			Path:   server.URL + path,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(logged) != 1 || logged[0] != "/slow" {
+		t.Errorf("expected %v, received %v", []string{"/slow"}, logged)
+	}
+
+	logged = nil
+	r.SetSlowRequestThreshold(0)
+	err := r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   server.URL + "/slow",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logged) != 0 {
+		t.Errorf("expected no slow requests logged when disabled, received %v", logged)
+	}
+}
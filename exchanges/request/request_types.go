@@ -25,6 +25,9 @@ var (
 	MaxRetryAttempts = DefaultMaxRetryAttempts
 )
 
+// logSlow is called when a request exceeds the requester's slow threshold
+var logSlow = logSlowRequest
+
 // Requester struct for the request client
 type Requester struct {
 	HTTPClient         *http.Client
@@ -38,6 +41,9 @@ type Requester struct {
 	backoff            Backoff
 	retryPolicy        RetryPolicy
 	timedLock          *timedmutex.TimedMutex
+	// slowThreshold is the response time above which a request is logged as
+	// slow, zero disables logging
+	slowThreshold time.Duration
 }
 
 // Item is a temp item for requests
@@ -285,3 +285,12 @@ func (w *Orderbook) FlushBuffer() {
 	w.buffer = nil
 	w.m.Unlock()
 }
+
+// FlushOrderbook removes a single orderbook and its buffered updates so no
+// further updates are applied until a fresh snapshot is loaded
+func (w *Orderbook) FlushOrderbook(p currency.Pair, a asset.Item) {
+	w.m.Lock()
+	delete(w.ob[p], a)
+	delete(w.buffer[p], a)
+	w.m.Unlock()
+}
@@ -627,6 +627,27 @@ func TestFlushbuffer(t *testing.T) {
 	}
 }
 
+func TestFlushOrderbook(t *testing.T) {
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	obl.FlushOrderbook(cp, asset.Spot)
+	if _, ok := obl.ob[cp][asset.Spot]; ok {
+		t.Error("expected ob be flushed")
+	}
+	err = obl.Update(&Update{
+		Asks:  []orderbook.Item{{Price: 1, Amount: 1}},
+		Pair:  cp,
+		Asset: asset.Spot,
+	})
+	if err == nil {
+		t.Error("expected update to a flushed ob to fail")
+	}
+	// Flushing an orderbook which has not been loaded should not panic
+	obl.FlushOrderbook(cp, asset.Futures)
+}
+
 // TestInsertingSnapShots logic test
 func TestInsertingSnapShots(t *testing.T) {
 	var obl Orderbook
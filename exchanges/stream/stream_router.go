@@ -0,0 +1,80 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	errRouteChannelEmpty = errors.New("route channel cannot be empty")
+	errRouteHandlerNil   = errors.New("route handler cannot be nil")
+	errRouteExists       = errors.New("route already registered")
+)
+
+// RouteHandler processes a raw websocket payload received on a channel
+type RouteHandler func(channel string, data []byte) error
+
+// NewRouter returns a new websocket channel router
+func NewRouter() *Router {
+	return &Router{
+		handlers: make(map[string]RouteHandler),
+	}
+}
+
+// Router dispatches raw websocket payloads to the handler registered for the
+// payload's channel, so each channel's processing lives in its own handler
+// rather than in a single demultiplexing switch
+type Router struct {
+	handlers map[string]RouteHandler
+	// prefixes are kept longest first so the most specific route wins
+	prefixes []string
+	m        sync.RWMutex
+}
+
+// Register adds a handler for channels matching the supplied prefix e.g.
+// "tradeHistory" handles "tradeHistory:BTC-USD"
+func (r *Router) Register(prefix string, h RouteHandler) error {
+	if prefix == "" {
+		return errRouteChannelEmpty
+	}
+	if h == nil {
+		return errRouteHandlerNil
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if _, ok := r.handlers[prefix]; ok {
+		return fmt.Errorf("%w: %s", errRouteExists, prefix)
+	}
+	r.handlers[prefix] = h
+	r.prefixes = append(r.prefixes, prefix)
+	sort.Slice(r.prefixes, func(i, j int) bool {
+		return len(r.prefixes[i]) > len(r.prefixes[j])
+	})
+	return nil
+}
+
+// Route sends the payload to the handler registered for the channel, returning
+// false if no handler matches. A nil router matches no channels
+func (r *Router) Route(channel string, data []byte) (bool, error) {
+	if r == nil {
+		return false, nil
+	}
+	r.m.RLock()
+	h, ok := r.handlers[channel]
+	if !ok {
+		for i := range r.prefixes {
+			if strings.HasPrefix(channel, r.prefixes[i]) {
+				h, ok = r.handlers[r.prefixes[i]], true
+				break
+			}
+		}
+	}
+	r.m.RUnlock()
+	if !ok {
+		return false, nil
+	}
+	return true, h(channel, data)
+}
@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+	r := NewRouter()
+	var routed []string
+	handler := func(name string) RouteHandler {
+		return func(channel string, data []byte) error {
+			routed = append(routed, name+"|"+channel+"|"+string(data))
+			return nil
+		}
+	}
+
+	if err := r.Register("", handler("empty")); !errors.Is(err, errRouteChannelEmpty) {
+		t.Errorf("expected %v, received %v", errRouteChannelEmpty, err)
+	}
+	if err := r.Register("trade", nil); !errors.Is(err, errRouteHandlerNil) {
+		t.Errorf("expected %v, received %v", errRouteHandlerNil, err)
+	}
+	if err := r.Register("trade", handler("trade")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("tradeHistory", handler("history")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("trade", handler("trade")); !errors.Is(err, errRouteExists) {
+		t.Errorf("expected %v, received %v", errRouteExists, err)
+	}
+
+	handled, err := r.Route("tradeHistory:BTC-USD", []byte("1"))
+	if err != nil || !handled {
+		t.Fatalf("expected route to be handled, received %v %v", handled, err)
+	}
+	handled, err = r.Route("trade", []byte("2"))
+	if err != nil || !handled {
+		t.Fatalf("expected route to be handled, received %v %v", handled, err)
+	}
+	handled, err = r.Route("orderbook:BTC-USD", []byte("3"))
+	if err != nil || handled {
+		t.Fatalf("expected route to be unhandled, received %v %v", handled, err)
+	}
+
+	expected := []string{"history|tradeHistory:BTC-USD|1", "trade|trade|2"}
+	if len(routed) != len(expected) {
+		t.Fatalf("expected %v, received %v", expected, routed)
+	}
+	for i := range expected {
+		if routed[i] != expected[i] {
+			t.Errorf("expected %v, received %v", expected[i], routed[i])
+		}
+	}
+
+	errHandler := errors.New("handler error")
+	if err = r.Register("error", func(string, []byte) error { return errHandler }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Route("error", nil); !errors.Is(err, errHandler) {
+		t.Errorf("expected %v, received %v", errHandler, err)
+	}
+}
+
+func TestRouterNil(t *testing.T) {
+	t.Parallel()
+	var r *Router
+	handled, err := r.Route("ticker", []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handled {
+		t.Error("expected a nil router to leave the payload unhandled")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"strings"
 
-	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 )
 
@@ -19,7 +18,7 @@ func Validate(request *Request) (err error) {
 		allErrors = append(allErrors, ErrStrAmountMustBeGreaterThanZero)
 	}
 
-	if (request.Currency == currency.Code{}) {
+	if request.Currency.IsEmpty() {
 		allErrors = append(allErrors, ErrStrNoCurrencySet)
 	}
 
@@ -28,7 +27,7 @@ func Validate(request *Request) (err error) {
 		if request.Fiat == nil {
 			return ErrInvalidRequest
 		}
-		if (request.Currency != currency.Code{}) && !request.Currency.IsFiatCurrency() {
+		if !request.Currency.IsEmpty() && !request.Currency.IsFiatCurrency() {
 			allErrors = append(allErrors, ErrStrCurrencyNotFiat)
 		}
 		allErrors = append(allErrors, validateFiat(request)...)
@@ -36,7 +35,7 @@ func Validate(request *Request) (err error) {
 		if request.Crypto == nil {
 			return ErrInvalidRequest
 		}
-		if (request.Currency != currency.Code{}) && !request.Currency.IsCryptocurrency() {
+		if !request.Currency.IsEmpty() && !request.Currency.IsCryptocurrency() {
 			allErrors = append(allErrors, ErrStrCurrencyNotCrypto)
 		}
 		allErrors = append(allErrors, validateCrypto(request)...)
@@ -68,6 +67,10 @@ func validateCrypto(request *Request) (err []string) {
 		err = append(err, ErrStrExchangeNotSupportedByAddress)
 	}
 
+	if !IsAddressWhitelisted(request.Exchange, request.Currency, request.Crypto.Address) {
+		err = append(err, ErrStrAddressNotInExchangeWhitelist)
+	}
+
 	if request.Crypto.Address == "" {
 		err = append(err, ErrStrAddressNotSet)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/thrasher-corp/gocryptotrader/core"
@@ -269,3 +270,33 @@ func TestValidateCrypto(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEmptyCurrency(t *testing.T) {
+	for _, c := range []currency.Code{{}, currency.NewCode("")} {
+		fiat := &Request{
+			Fiat: &FiatRequest{
+				Bank: &banking.Account{},
+			},
+			Currency: c,
+			Amount:   1,
+			Type:     Fiat,
+		}
+		err := Validate(fiat)
+		if err == nil || !strings.Contains(err.Error(), ErrStrNoCurrencySet) {
+			t.Errorf("expected %v, received %v", ErrStrNoCurrencySet, err)
+		}
+
+		crypto := &Request{
+			Crypto: &CryptoRequest{
+				Address: core.BitcoinDonationAddress,
+			},
+			Currency: c,
+			Amount:   1,
+			Type:     Crypto,
+		}
+		err = Validate(crypto)
+		if err == nil || err.Error() != ErrStrNoCurrencySet {
+			t.Errorf("expected %v, received %v", ErrStrNoCurrencySet, err)
+		}
+	}
+}
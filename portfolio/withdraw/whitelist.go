@@ -0,0 +1,45 @@
+package withdraw
+
+import (
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// LoadAddressWhitelist replaces the crypto withdrawal address whitelist for
+// an exchange, keyed by currency code. Currencies without any addresses are
+// unrestricted
+func LoadAddressWhitelist(exchange string, whitelist map[string][]string) {
+	list := make(map[string][]string)
+	for code, addresses := range whitelist {
+		if len(addresses) == 0 {
+			continue
+		}
+		list[strings.ToUpper(code)] = append([]string(nil), addresses...)
+	}
+
+	whitelistMtx.Lock()
+	defer whitelistMtx.Unlock()
+	if len(list) == 0 {
+		delete(addressWhitelist, strings.ToLower(exchange))
+		return
+	}
+	addressWhitelist[strings.ToLower(exchange)] = list
+}
+
+// IsAddressWhitelisted returns whether an address may be withdrawn to for the
+// supplied exchange and currency, an empty whitelist allows all addresses
+func IsAddressWhitelisted(exchange string, c currency.Code, address string) bool {
+	whitelistMtx.RLock()
+	defer whitelistMtx.RUnlock()
+	addresses, ok := addressWhitelist[strings.ToLower(exchange)][c.Upper().String()]
+	if !ok {
+		return true
+	}
+	for x := range addresses {
+		if addresses[x] == address {
+			return true
+		}
+	}
+	return false
+}
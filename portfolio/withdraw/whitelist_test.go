@@ -0,0 +1,62 @@
+package withdraw
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/core"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestIsAddressWhitelisted(t *testing.T) {
+	const exch = "whitelist-test"
+	defer LoadAddressWhitelist(exch, nil)
+
+	if !IsAddressWhitelisted(exch, currency.BTC, testBTCAddress) {
+		t.Error("expected an empty whitelist to allow all addresses")
+	}
+
+	LoadAddressWhitelist(exch, map[string][]string{
+		"btc": {core.BitcoinDonationAddress},
+		"ltc": nil,
+	})
+	if !IsAddressWhitelisted("WHITELIST-TEST", currency.BTC, core.BitcoinDonationAddress) {
+		t.Error("expected whitelisted address to be allowed")
+	}
+	if IsAddressWhitelisted(exch, currency.BTC, testBTCAddress) {
+		t.Error("expected address missing from whitelist to be blocked")
+	}
+	if !IsAddressWhitelisted(exch, currency.LTC, testBTCAddress) {
+		t.Error("expected currency without whitelist entries to allow all addresses")
+	}
+}
+
+func TestValidateAddressWhitelist(t *testing.T) {
+	const exch = "Binance"
+	defer LoadAddressWhitelist(exch, nil)
+
+	req := &Request{
+		Exchange: exch,
+		Crypto: &CryptoRequest{
+			Address: core.BitcoinDonationAddress,
+		},
+		Currency: currency.BTC,
+		Amount:   0.1,
+		Type:     Crypto,
+	}
+
+	LoadAddressWhitelist(exch, map[string][]string{
+		"BTC": {core.BitcoinDonationAddress},
+	})
+	err := Validate(req)
+	if err != nil {
+		t.Errorf("expected %v, received %v", nil, err)
+	}
+
+	LoadAddressWhitelist(exch, map[string][]string{
+		"BTC": {testBTCAddress},
+	})
+	err = Validate(req)
+	if err == nil || err.Error() != ErrStrAddressNotInExchangeWhitelist {
+		t.Errorf("expected %v, received %v", ErrStrAddressNotInExchangeWhitelist, err)
+	}
+}
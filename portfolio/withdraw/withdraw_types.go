@@ -2,6 +2,7 @@ package withdraw
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -41,6 +42,8 @@ const (
 	ErrStrAddressNotWhiteListed = "address is not whitelisted for withdrawals"
 	// ErrStrExchangeNotSupportedByAddress message to return when attemptign to withdraw to an unsupported exchange
 	ErrStrExchangeNotSupportedByAddress = "address is not supported by exchange"
+	// ErrStrAddressNotInExchangeWhitelist message to return when an address is not in the exchange withdrawal whitelist
+	ErrStrAddressNotInExchangeWhitelist = "address is not in the exchange withdrawal whitelist"
 )
 
 var (
@@ -54,6 +57,9 @@ var (
 	Cache = cache.New(CacheSize)
 	// DryRunID uuid to use for dryruns
 	DryRunID, _ = uuid.FromString("3e7e2c25-5a0b-429b-95a1-0960079dce56")
+
+	addressWhitelist = make(map[string]map[string][]string)
+	whitelistMtx     sync.RWMutex
 )
 
 // CryptoRequest stores the info required for a crypto withdrawal request
@@ -104,6 +110,11 @@ type Response struct {
 
 	Exchange       *ExchangeResponse `json:"exchange"`
 	RequestDetails *Request          `json:"request_details"`
+	// DryRun is set when the request was not submitted to the exchange
+	DryRun bool `json:"dry_run"`
+	// CorrelationID is generated locally when the event is stored and is
+	// used to update the event once the exchange confirms its status
+	CorrelationID uuid.UUID `json:"correlation_id"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`